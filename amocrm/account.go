@@ -3,6 +3,8 @@ package amocrm
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 )
 
 // Account represents AmoCRM account information
@@ -23,6 +25,7 @@ type Account struct {
 	IsLossReasonEnabled     bool             `json:"is_loss_reason_enabled"`
 	IsHelpbotEnabled        bool             `json:"is_helpbot_enabled"`
 	IsTechnicalAccount      bool             `json:"is_technical_account"`
+	IsTaskResultRequired    bool             `json:"is_task_result_required"`
 	ContactNameDisplayOrder int              `json:"contact_name_display_order"`
 	AmojoID                 string           `json:"amojo_id,omitempty"`
 	UUID                    string           `json:"uuid,omitempty"`
@@ -32,8 +35,30 @@ type Account struct {
 
 // AccountEmbedded represents embedded account data
 type AccountEmbedded struct {
-	Users  []User  `json:"users,omitempty"`
-	Groups []Group `json:"groups,omitempty"`
+	Users            []User            `json:"users,omitempty"`
+	Groups           []Group           `json:"groups,omitempty"`
+	DatetimeSettings *DatetimeSettings `json:"datetime_settings,omitempty"`
+}
+
+// DatetimeSettings represents the account date and time settings,
+// returned with with=datetime_settings
+type DatetimeSettings struct {
+	DatePattern      string `json:"date_pattern"`
+	ShortDatePattern string `json:"short_date_pattern"`
+	ShortTimePattern string `json:"short_time_pattern"`
+	DateFormat       string `json:"date_formant"`
+	TimeFormat       string `json:"time_format"`
+	Timezone         string `json:"timezone"` // IANA name, e.g. Europe/Moscow
+	TimezoneOffset   string `json:"timezone_offset"`
+}
+
+// Location returns the account timezone. It requires the account to be
+// fetched with datetime settings.
+func (a *Account) Location() (*time.Location, error) {
+	if a.Embedded == nil || a.Embedded.DatetimeSettings == nil || a.Embedded.DatetimeSettings.Timezone == "" {
+		return nil, fmt.Errorf("account timezone is not available; fetch the account with datetime_settings")
+	}
+	return time.LoadLocation(a.Embedded.DatetimeSettings.Timezone)
 }
 
 // User represents an AmoCRM user
@@ -63,14 +88,22 @@ type Group struct {
 // AccountService handles communication with account-related methods
 type AccountService struct {
 	client *Client
+
+	locationMu sync.Mutex
+	location   *time.Location
+
+	taskResultMu       sync.Mutex
+	taskResultRequired *bool
 }
 
-// Get retrieves account information
+// Get retrieves account information. If the account subdomain differs from
+// the configured one, the client switches to the new subdomain.
 func (s *AccountService) Get(ctx context.Context) (*Account, error) {
 	var account Account
 	if err := s.client.GetJSON(ctx, "/account", &account); err != nil {
 		return nil, err
 	}
+	s.client.updateSubdomain(ctx, account.Subdomain)
 	return &account, nil
 }
 
@@ -80,6 +113,7 @@ func (s *AccountService) GetWithUsers(ctx context.Context) (*Account, error) {
 	if err := s.client.GetJSON(ctx, "/account?with=users", &account); err != nil {
 		return nil, err
 	}
+	s.client.updateSubdomain(ctx, account.Subdomain)
 	return &account, nil
 }
 
@@ -89,5 +123,73 @@ func (s *AccountService) GetWithUsersAndGroups(ctx context.Context) (*Account, e
 	if err := s.client.GetJSON(ctx, "/account?with=users,groups", &account); err != nil {
 		return nil, err
 	}
+	s.client.updateSubdomain(ctx, account.Subdomain)
+	return &account, nil
+}
+
+// GetWithDatetimeSettings retrieves account information with date and time
+// settings, including the account timezone
+func (s *AccountService) GetWithDatetimeSettings(ctx context.Context) (*Account, error) {
+	var account Account
+	if err := s.client.GetJSON(ctx, "/account?with=datetime_settings", &account); err != nil {
+		return nil, err
+	}
+	s.client.updateSubdomain(ctx, account.Subdomain)
 	return &account, nil
 }
+
+// Location returns the account timezone. AmoCRM interprets wall-clock
+// times such as task deadlines and date custom field values in this
+// timezone. The timezone is fetched once and cached.
+func (s *AccountService) Location(ctx context.Context) (*time.Location, error) {
+	s.locationMu.Lock()
+	defer s.locationMu.Unlock()
+
+	if s.location != nil {
+		return s.location, nil
+	}
+
+	account, err := s.GetWithDatetimeSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := account.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	s.location = loc
+	return loc, nil
+}
+
+// TaskResultRequired reports whether the account requires a result text
+// when completing tasks. The flag is fetched once and cached. It is not
+// part of the documented API: accounts that don't return it report false
+// and AmoCRM's own validation error surfaces on completion instead.
+func (s *AccountService) TaskResultRequired(ctx context.Context) (bool, error) {
+	s.taskResultMu.Lock()
+	defer s.taskResultMu.Unlock()
+
+	if s.taskResultRequired != nil {
+		return *s.taskResultRequired, nil
+	}
+
+	// Only the flag is needed, so the subdomain check of Get is skipped
+	var account Account
+	if err := s.client.GetJSON(ctx, "/account", &account); err != nil {
+		return false, err
+	}
+
+	s.taskResultRequired = &account.IsTaskResultRequired
+	return account.IsTaskResultRequired, nil
+}
+
+// Date returns the time of the given wall clock in the account timezone,
+// e.g. for Task.CompleteTill or a date custom field value (via Unix).
+func (s *AccountService) Date(ctx context.Context, year int, month time.Month, day, hour, min int) (time.Time, error) {
+	loc, err := s.Location(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(year, month, day, hour, min, 0, 0, loc), nil
+}
@@ -0,0 +1,107 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAccountSubdomainChange(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Acme", "subdomain": "renamed"}`))
+	})
+
+	var oldSub, newSub string
+	client.onSubdomainChange = func(o, n string) {
+		oldSub, newSub = o, n
+	}
+
+	if _, err := client.Account.Get(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if oldSub != "test" || newSub != "renamed" {
+		t.Errorf("Expected callback test -> renamed, got %s -> %s", oldSub, newSub)
+	}
+
+	if got := client.apiBaseURL(); got != "https://renamed.amocrm.ru/api/v4" {
+		t.Errorf("Unexpected base URL after rename '%s'", got)
+	}
+
+	if got := client.accountDomain(); got != "renamed.amocrm.ru" {
+		t.Errorf("Unexpected account domain after rename '%s'", got)
+	}
+}
+
+func TestAccountSubdomainChangeKeepsBaseURL(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Acme", "subdomain": "other"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithSubdomain("test"),
+		WithPermanentToken("test-token"),
+		WithBaseURL(server.URL+"/api/v4"),
+	)
+
+	ctx := context.Background()
+	if _, err := client.Account.Get(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := client.accountDomain(); got != "other.amocrm.ru" {
+		t.Errorf("Expected the token key to follow the rename, got '%s'", got)
+	}
+	if got := client.apiBaseURL(); got != server.URL+"/api/v4" {
+		t.Errorf("Expected the custom base URL to be kept, got '%s'", got)
+	}
+
+	if _, err := client.Account.Get(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected both requests to reach the test server, got %d", requests)
+	}
+}
+
+func TestAccountService_Location(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("with") != "datetime_settings" {
+			t.Errorf("Expected with=datetime_settings, got '%s'", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "subdomain": "test", "_embedded": {"datetime_settings": {"timezone": "Asia/Yekaterinburg", "timezone_offset": "+05:00"}}}`))
+	})
+
+	ctx := context.Background()
+	task := &Task{Text: "Call back"}
+	if err := client.Tasks.DueAt(ctx, task, 2024, time.March, 1, 10, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 10:00 in Yekaterinburg (UTC+5) is 05:00 UTC
+	expected := time.Date(2024, time.March, 1, 5, 0, 0, 0, time.UTC).Unix()
+	if task.CompleteTill != expected {
+		t.Errorf("Expected complete_till %d, got %d", expected, task.CompleteTill)
+	}
+
+	loc, err := client.Account.Location(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loc.String() != "Asia/Yekaterinburg" {
+		t.Errorf("Expected Asia/Yekaterinburg, got %s", loc)
+	}
+	if requests != 1 {
+		t.Errorf("Expected timezone to be cached after 1 request, got %d", requests)
+	}
+}
@@ -0,0 +1,131 @@
+// Package amocrmtest provides helpers for testing code that uses the amocrm
+// client against a fake AmoCRM server.
+//
+// Example usage:
+//
+//	mux := amocrmtest.NewMux()
+//	mux.HandleJSON(http.MethodGet, "/leads/1", http.StatusOK, `{"id": 1, "name": "Сделка"}`)
+//
+//	client, server := amocrmtest.NewTestClient(mux)
+//	defer server.Close()
+//
+//	lead, err := client.Leads.GetByID(ctx, 1)
+package amocrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/dedomorozoff/amocrm-go-v4/amocrm"
+)
+
+// APIPrefix is the path prefix of API requests sent to the test server
+const APIPrefix = "/api/v4"
+
+// NewTestClient starts a test server with the handler and returns a client
+// pointed at it. The client uses a permanent token and no rate limit; extra
+// options are applied after these defaults. The caller must close the server.
+func NewTestClient(handler http.Handler, opts ...amocrm.ClientOption) (*amocrm.Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	defaults := []amocrm.ClientOption{
+		amocrm.WithSubdomain("test"),
+		amocrm.WithPermanentToken("test-token"),
+		amocrm.WithBaseURL(server.URL + APIPrefix),
+		amocrm.WithRateLimit(1000),
+	}
+
+	client := amocrm.NewClient(append(defaults, opts...)...)
+	return client, server
+}
+
+// Mux is an http.Handler serving canned responses registered per method and
+// API path (without the /api/v4 prefix). Requests to unregistered paths get
+// a 404 response in the format of the AmoCRM API.
+type Mux struct {
+	mu     sync.Mutex
+	routes map[string]response
+	calls  map[string]int
+}
+
+// response is a canned response
+type response struct {
+	status int
+	body   []byte
+}
+
+// NewMux creates an empty Mux
+func NewMux() *Mux {
+	return &Mux{
+		routes: make(map[string]response),
+		calls:  make(map[string]int),
+	}
+}
+
+// Handle registers a raw response body for a method and path
+func (m *Mux) Handle(method, path string, status int, body string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes[routeKey(method, path)] = response{status: status, body: []byte(body)}
+}
+
+// HandleJSON registers a JSON response for a method and path. The body may
+// be a JSON string or any value to be marshaled.
+func (m *Mux) HandleJSON(method, path string, status int, body interface{}) {
+	var data []byte
+	switch v := body.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		data, err = json.Marshal(v)
+		if err != nil {
+			panic(fmt.Sprintf("amocrmtest: failed to marshal response for %s %s: %v", method, path, err))
+		}
+	}
+
+	m.Handle(method, path, status, string(data))
+}
+
+// Calls returns how many requests were served for a method and path
+func (m *Mux) Calls(method, path string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[routeKey(method, path)]
+}
+
+// ServeHTTP implements http.Handler
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := routeKey(r.Method, strings.TrimPrefix(r.URL.Path, APIPrefix))
+
+	m.mu.Lock()
+	resp, ok := m.routes[key]
+	if ok {
+		m.calls[key]++
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"title": "Not Found", "status": 404, "detail": "no canned response for %s"}`, key)
+		return
+	}
+
+	if len(resp.body) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(resp.status)
+	w.Write(resp.body)
+}
+
+// routeKey builds the lookup key of a route
+func routeKey(method, path string) string {
+	return method + " " + path
+}
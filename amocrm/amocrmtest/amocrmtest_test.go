@@ -0,0 +1,47 @@
+package amocrmtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dedomorozoff/amocrm-go-v4/amocrm"
+)
+
+func TestMux(t *testing.T) {
+	mux := NewMux()
+	mux.HandleJSON(http.MethodGet, "/leads/1", http.StatusOK, `{"id": 1, "name": "Сделка"}`)
+	mux.HandleJSON(http.MethodGet, "/contacts/2", http.StatusOK, amocrm.Contact{ID: 2, Name: "Иван"})
+
+	client, server := NewTestClient(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	lead, err := client.Leads.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if lead.Name != "Сделка" {
+		t.Errorf("Expected lead name 'Сделка', got '%s'", lead.Name)
+	}
+
+	contact, err := client.Contacts.GetByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if contact.Name != "Иван" {
+		t.Errorf("Expected contact name 'Иван', got '%s'", contact.Name)
+	}
+
+	if mux.Calls(http.MethodGet, "/leads/1") != 1 {
+		t.Errorf("Expected 1 call, got %d", mux.Calls(http.MethodGet, "/leads/1"))
+	}
+
+	_, err = client.Leads.GetByID(ctx, 3)
+	apiErr, ok := err.(*amocrm.APIError)
+	if !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 API error, got %v", err)
+	}
+}
@@ -2,11 +2,11 @@ package amocrm
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 )
 
 // AuthService handles OAuth 2.0 authentication
@@ -16,12 +16,19 @@ type AuthService struct {
 
 // ExchangeCode exchanges an authorization code for access and refresh tokens
 func (s *AuthService) ExchangeCode(ctx context.Context, code string) error {
+	_, err := s.ExchangeCodeToken(ctx, code)
+	return err
+}
+
+// ExchangeCodeToken exchanges an authorization code for access and refresh
+// tokens and returns the new token, e.g. to inspect its expiry
+func (s *AuthService) ExchangeCodeToken(ctx context.Context, code string) (*Token, error) {
 	if s.client.authType != AuthTypeOAuth2 {
-		return fmt.Errorf("OAuth2 is not configured")
+		return nil, fmt.Errorf("OAuth2 is not configured")
 	}
 
 	if s.client.oauth2Config == nil {
-		return fmt.Errorf("OAuth2 config is missing")
+		return nil, fmt.Errorf("OAuth2 config is missing")
 	}
 
 	// Prepare request
@@ -32,38 +39,30 @@ func (s *AuthService) ExchangeCode(ctx context.Context, code string) error {
 	data.Set("code", code)
 	data.Set("redirect_uri", s.client.oauth2Config.RedirectURI)
 
-	tokenURL := fmt.Sprintf("https://%s.%s/oauth2/access_token", s.client.subdomain, s.client.domain)
-	req, err := s.client.httpClient.Post(tokenURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+	token, err := s.client.requestToken(ctx, data)
 	if err != nil {
-		return fmt.Errorf("failed to exchange code: %w", err)
-	}
-	defer req.Body.Close()
-
-	if req.StatusCode != 200 {
-		return fmt.Errorf("token exchange failed with status %d", req.StatusCode)
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
 	}
 
-	var token Token
-	if err := json.NewDecoder(req.Body).Decode(&token); err != nil {
-		return fmt.Errorf("failed to decode token: %w", err)
+	if missing := token.MissingScopes(s.client.requiredScopes...); len(missing) > 0 {
+		return nil, fmt.Errorf("token is missing required scopes: %s", strings.Join(missing, ", "))
 	}
 
-	token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
-
 	// Save token
 	s.client.tokenMu.Lock()
-	s.client.currentToken = &token
+	s.client.currentToken = token
 	s.client.tokenMu.Unlock()
 
 	// Persist token
+	var saveErr error
 	if s.client.tokenStorage != nil {
-		domain := fmt.Sprintf("%s.%s", s.client.subdomain, s.client.domain)
-		if err := s.client.tokenStorage.Save(ctx, domain, &token); err != nil {
-			return fmt.Errorf("failed to save token: %w", err)
+		if err := s.client.tokenStorage.Save(ctx, s.client.accountDomain(), token); err != nil {
+			saveErr = fmt.Errorf("failed to save token: %w", err)
 		}
 	}
 
-	return nil
+	s.client.notifyTokenRefresh(token)
+	return token, saveErr
 }
 
 // GetAuthorizationURL returns the OAuth2 authorization URL
@@ -89,18 +88,71 @@ func (s *AuthService) GetAuthorizationURL(state string, mode string) (string, er
 		params.Set("mode", mode) // popup or post_message
 	}
 
-	authURL := fmt.Sprintf("https://%s.%s/oauth?%s", s.client.subdomain, s.client.domain, params.Encode())
+	authURL := s.client.oauthURL(s.client.oauthAuthorizePath) + "?" + params.Encode()
 	return authURL, nil
 }
 
 // RefreshToken manually refreshes the OAuth2 token
 func (s *AuthService) RefreshToken(ctx context.Context) error {
-	return s.client.refreshToken(ctx)
+	return s.client.refreshToken(ctx, nil)
 }
 
 // GetCurrentToken returns the current OAuth2 token
 func (s *AuthService) GetCurrentToken() *Token {
-	s.client.tokenMu.RLock()
-	defer s.client.tokenMu.RUnlock()
-	return s.client.currentToken
+	return s.client.token()
+}
+
+// Scopes returns the scopes granted to the token. They are taken from the
+// scope field of the token response when present, otherwise from the
+// "scopes" claim of the JWT access token. Nil means the scopes are unknown.
+func (t *Token) Scopes() []string {
+	if t.Scope != "" {
+		return strings.Fields(t.Scope)
+	}
+
+	parts := strings.Split(t.AccessToken, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+
+	var claims struct {
+		Scopes []string `json:"scopes"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil
+	}
+
+	return claims.Scopes
+}
+
+// MissingScopes returns the required scopes the token was not granted.
+// When the granted scopes are unknown nothing is reported as missing.
+func (t *Token) MissingScopes(required ...string) []string {
+	if len(required) == 0 {
+		return nil
+	}
+
+	granted := t.Scopes()
+	if granted == nil {
+		return nil
+	}
+
+	has := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		has[scope] = true
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if !has[scope] {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
 }
@@ -0,0 +1,159 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTokenScopes(t *testing.T) {
+	token := &Token{Scope: "crm notifications"}
+	if got := token.Scopes(); !reflect.DeepEqual(got, []string{"crm", "notifications"}) {
+		t.Errorf("Unexpected scopes from scope field: %v", got)
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"scopes":["crm","files"]}`))
+	token = &Token{AccessToken: "header." + payload + ".signature"}
+	if got := token.Scopes(); !reflect.DeepEqual(got, []string{"crm", "files"}) {
+		t.Errorf("Unexpected scopes from JWT claims: %v", got)
+	}
+
+	if missing := token.MissingScopes("crm", "notifications"); !reflect.DeepEqual(missing, []string{"notifications"}) {
+		t.Errorf("Expected notifications to be missing, got %v", missing)
+	}
+
+	if missing := (&Token{AccessToken: "opaque"}).MissingScopes("crm"); missing != nil {
+		t.Errorf("Unknown scopes should not be reported missing, got %v", missing)
+	}
+}
+
+func TestExchangeCodeRequiredScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "a", "refresh_token": "r", "token_type": "Bearer", "expires_in": 86400, "scope": "crm"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithSubdomain("test"),
+		WithOAuth2("client-id", "client-secret", "https://example.com/callback"),
+		WithOAuthEndpoints(server.URL+"/oauth2/access_token", ""),
+		WithRequiredScopes("crm", "notifications"),
+	)
+
+	if err := client.Auth.ExchangeCode(context.Background(), "code"); err == nil {
+		t.Fatal("Expected error for missing notifications scope")
+	}
+
+	if client.Auth.GetCurrentToken() != nil {
+		t.Error("Token with missing scopes should not be stored")
+	}
+}
+
+func TestExchangeCodeToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth2/access_token" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Failed to parse form: %v", err)
+		}
+		if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != "auth-code" || r.PostForm.Get("client_secret") != "client-secret" {
+			t.Errorf("Unexpected form: %v", r.PostForm)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "a", "refresh_token": "r", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithSubdomain("test"),
+		WithOAuth2("client-id", "client-secret", "https://example.com/callback"),
+		WithOAuthEndpoints(server.URL+"/oauth2/access_token", ""),
+	)
+
+	before := time.Now()
+	token, err := client.Auth.ExchangeCodeToken(context.Background(), "auth-code")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if token.AccessToken != "a" || token.RefreshToken != "r" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+	if token.ExpiresAt.Before(before.Add(time.Hour)) || token.ExpiresAt.After(time.Now().Add(time.Hour)) {
+		t.Errorf("Expected the token to expire in an hour, got %v", token.ExpiresAt)
+	}
+	if client.Auth.GetCurrentToken() != token {
+		t.Error("Expected the exchanged token to become the current token")
+	}
+}
+
+func TestExchangeCodeTokenError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"title": "Bad Request", "detail": "Authorization code has expired"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithSubdomain("test"),
+		WithOAuth2("client-id", "client-secret", "https://example.com/callback"),
+		WithOAuthEndpoints(server.URL+"/oauth2/access_token", ""),
+	)
+
+	_, err := client.Auth.ExchangeCodeToken(context.Background(), "expired")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected a 400 APIError, got %v", err)
+	}
+}
+
+func TestTokenRefreshCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("grant_type") == "refresh_token" {
+			w.Write([]byte(`{"access_token": "refreshed", "refresh_token": "r2", "expires_in": 86400}`))
+			return
+		}
+		w.Write([]byte(`{"access_token": "exchanged", "refresh_token": "r1", "expires_in": 86400}`))
+	}))
+	defer server.Close()
+
+	var domains, tokens []string
+	var client *Client
+	client = NewClient(
+		WithSubdomain("test"),
+		WithOAuth2("client-id", "client-secret", "https://example.com/callback"),
+		WithOAuthEndpoints(server.URL+"/oauth2/access_token", ""),
+		WithTokenRefreshCallback(func(domain string, token *Token) {
+			if client.Auth.GetCurrentToken() != token {
+				t.Error("Expected the new token to be current when the callback runs")
+			}
+			domains = append(domains, domain)
+			tokens = append(tokens, token.AccessToken)
+		}),
+	)
+
+	ctx := context.Background()
+	if err := client.Auth.ExchangeCode(ctx, "code"); err != nil {
+		t.Fatalf("Unexpected exchange error: %v", err)
+	}
+	if err := client.Auth.RefreshToken(ctx); err != nil {
+		t.Fatalf("Unexpected refresh error: %v", err)
+	}
+
+	if !reflect.DeepEqual(tokens, []string{"exchanged", "refreshed"}) {
+		t.Errorf("Expected callbacks for both tokens, got %v", tokens)
+	}
+	if !reflect.DeepEqual(domains, []string{"test.amocrm.ru", "test.amocrm.ru"}) {
+		t.Errorf("Expected the account domain, got %v", domains)
+	}
+}
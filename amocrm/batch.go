@@ -0,0 +1,109 @@
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// BatchResult reports the outcome of a detailed batch create. Both maps
+// are keyed by the index of the entity in the request.
+type BatchResult[T any] struct {
+	Created map[int]T
+	Errors  map[int]error
+}
+
+// createBatchDetailed creates items with create. When AmoCRM rejects the
+// batch with validation errors pointing at some of the items, those items
+// are reported in Errors and the remaining ones are submitted again, so
+// valid rows are still created. Errors not tied to items are returned as
+// is.
+func createBatchDetailed[T any](ctx context.Context, items []*T, create func(context.Context, []*T) ([]T, error)) (*BatchResult[T], error) {
+	result := &BatchResult[T]{
+		Created: make(map[int]T),
+		Errors:  make(map[int]error),
+	}
+
+	pending := make([]int, len(items))
+	for i := range items {
+		pending[i] = i
+	}
+
+	for len(pending) > 0 {
+		batch := make([]*T, len(pending))
+		for i, index := range pending {
+			batch[i] = items[index]
+		}
+
+		created, err := create(ctx, batch)
+		if err == nil {
+			for i, entity := range created {
+				if i < len(pending) {
+					result.Created[pending[i]] = entity
+				}
+			}
+			return result, nil
+		}
+
+		itemErrs := batchItemErrors(err, len(pending))
+		if len(itemErrs) == 0 {
+			return result, err
+		}
+
+		var remaining []int
+		for i, index := range pending {
+			if itemErr, ok := itemErrs[i]; ok {
+				result.Errors[index] = itemErr
+			} else {
+				remaining = append(remaining, index)
+			}
+		}
+		pending = remaining
+	}
+
+	return result, nil
+}
+
+// batchItemErrors maps the validation errors of an API error to the
+// indexes of the rejected items
+func batchItemErrors(err error, n int) map[int]error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	grouped := make(map[int][]error)
+	for i := range apiErr.ValidationErrors {
+		v := &apiErr.ValidationErrors[i]
+		index, convErr := strconv.Atoi(v.RequestID)
+		if convErr != nil || index < 0 || index >= n {
+			continue
+		}
+		grouped[index] = append(grouped[index], v)
+	}
+
+	itemErrs := make(map[int]error, len(grouped))
+	for index, errs := range grouped {
+		itemErrs[index] = errors.Join(errs...)
+	}
+	return itemErrs
+}
+
+// CreateBatchDetailed creates multiple leads, reporting which leads were
+// created and which were rejected, keyed by their index in leads
+func (s *LeadsService) CreateBatchDetailed(ctx context.Context, leads []*Lead) (*BatchResult[Lead], error) {
+	return createBatchDetailed(ctx, leads, s.CreateBatch)
+}
+
+// CreateBatchDetailed creates multiple contacts, reporting which contacts
+// were created and which were rejected, keyed by their index in contacts
+func (s *ContactsService) CreateBatchDetailed(ctx context.Context, contacts []*Contact) (*BatchResult[Contact], error) {
+	return createBatchDetailed(ctx, contacts, s.CreateBatch)
+}
+
+// CreateBatchDetailed creates multiple companies, reporting which
+// companies were created and which were rejected, keyed by their index in
+// companies
+func (s *CompaniesService) CreateBatchDetailed(ctx context.Context, companies []*Company) (*BatchResult[Company], error) {
+	return createBatchDetailed(ctx, companies, s.CreateBatch)
+}
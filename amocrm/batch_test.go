@@ -0,0 +1,66 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestLeadsService_CreateBatchDetailed(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var body struct {
+			Leads []Lead `json:"leads"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if requests == 1 {
+			if len(body.Leads) != 3 {
+				t.Errorf("Expected 3 leads in first request, got %d", len(body.Leads))
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{
+				"title": "Bad Request",
+				"detail": "Request validation failed",
+				"validation-errors": [{"request_id": "1", "errors": [{"code": "InvalidType", "path": "price", "detail": "This value should be of type int."}]}]
+			}`))
+			return
+		}
+
+		if len(body.Leads) != 2 || body.Leads[0].Name != "A" || body.Leads[1].Name != "C" {
+			t.Errorf("Expected leads A and C to be resubmitted, got %+v", body.Leads)
+		}
+		w.Write([]byte(`{"_embedded": {"leads": [{"id": 10}, {"id": 12}]}}`))
+	})
+
+	leads := []*Lead{{Name: "A"}, {Name: "B"}, {Name: "C"}}
+	result, err := client.Leads.CreateBatchDetailed(context.Background(), leads)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Created) != 2 || result.Created[0].ID != 10 || result.Created[2].ID != 12 {
+		t.Errorf("Unexpected created leads: %+v", result.Created)
+	}
+	if len(result.Errors) != 1 || result.Errors[1] == nil {
+		t.Errorf("Expected an error for index 1, got %+v", result.Errors)
+	}
+}
+
+func TestContactsService_CreateBatchDetailedError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	result, err := client.Contacts.CreateBatchDetailed(context.Background(), []*Contact{{Name: "A"}})
+	if err == nil {
+		t.Fatal("Expected error for a failure not tied to items")
+	}
+	if len(result.Created) != 0 {
+		t.Errorf("Expected no created contacts, got %+v", result.Created)
+	}
+}
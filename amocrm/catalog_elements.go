@@ -0,0 +1,137 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+)
+
+// CatalogElementsService handles communication with catalog element
+// methods
+type CatalogElementsService struct {
+	client *Client
+}
+
+// CatalogElementsFilter represents filter options for listing catalog
+// elements
+type CatalogElementsFilter struct {
+	Query string
+	Limit int
+	Page  int
+	IDs   []int
+}
+
+// List retrieves a list of elements of a catalog
+func (s *CatalogElementsService) List(ctx context.Context, catalogID int, filter *CatalogElementsFilter) ([]CatalogElement, error) {
+	resp, err := s.ListWithResponse(ctx, catalogID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Elements, nil
+}
+
+// ListWithResponse retrieves a list of elements of a catalog with
+// pagination links
+func (s *CatalogElementsService) ListWithResponse(ctx context.Context, catalogID int, filter *CatalogElementsFilter) (*CatalogElementsResponse, error) {
+	path := fmt.Sprintf("/catalogs/%d/elements", catalogID)
+
+	if filter != nil {
+		path += "?"
+		if filter.Query != "" {
+			path += searchQuery(filter.Query)
+		}
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		for _, id := range filter.IDs {
+			path += fmt.Sprintf("filter[id][]=%d&", id)
+		}
+	}
+
+	var resp CatalogElementsResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetByID retrieves a catalog element by ID
+func (s *CatalogElementsService) GetByID(ctx context.Context, catalogID, elementID int) (*CatalogElement, error) {
+	path := fmt.Sprintf("/catalogs/%d/elements/%d", catalogID, elementID)
+
+	var element CatalogElement
+	if err := s.client.GetJSON(ctx, path, &element); err != nil {
+		return nil, err
+	}
+
+	return &element, nil
+}
+
+// Create creates a new catalog element
+func (s *CatalogElementsService) Create(ctx context.Context, catalogID int, element *CatalogElement) (*CatalogElement, error) {
+	elements, err := s.CreateBatch(ctx, catalogID, []*CatalogElement{element})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("no catalog element returned from API")
+	}
+
+	return &elements[0], nil
+}
+
+// CreateBatch creates multiple catalog elements in one request
+func (s *CatalogElementsService) CreateBatch(ctx context.Context, catalogID int, elements []*CatalogElement) ([]CatalogElement, error) {
+	type request struct {
+		Elements []CatalogElement `json:"elements"`
+	}
+
+	elementsValues := make([]CatalogElement, len(elements))
+	for i, e := range elements {
+		elementsValues[i] = *e
+	}
+
+	req := request{
+		Elements: elementsValues,
+	}
+
+	var resp CatalogElementsResponse
+	path := fmt.Sprintf("/catalogs/%d/elements", catalogID)
+	if err := s.client.PostJSON(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Elements, nil
+}
+
+// Update updates an existing catalog element
+func (s *CatalogElementsService) Update(ctx context.Context, catalogID int, element *CatalogElement) (*CatalogElement, error) {
+	if element.ID == 0 {
+		return nil, fmt.Errorf("catalog element ID is required for update")
+	}
+
+	type request struct {
+		Elements []CatalogElement `json:"elements"`
+	}
+
+	req := request{
+		Elements: []CatalogElement{*element},
+	}
+
+	var resp CatalogElementsResponse
+	path := fmt.Sprintf("/catalogs/%d/elements", catalogID)
+	if err := s.client.PatchJSON(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Embedded.Elements) == 0 {
+		return nil, fmt.Errorf("no catalog element returned from API")
+	}
+
+	return &resp.Embedded.Elements[0], nil
+}
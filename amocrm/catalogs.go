@@ -1,6 +1,10 @@
 package amocrm
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
 
 // Catalog represents an AmoCRM catalog
 type Catalog struct {
@@ -20,6 +24,31 @@ type Catalog struct {
 	AccountID       int    `json:"account_id,omitempty"`
 }
 
+// CatalogElement represents an element (e.g. a product) of a catalog
+type CatalogElement struct {
+	ID                 int                `json:"id,omitempty"`
+	CatalogID          int                `json:"catalog_id,omitempty"`
+	Name               string             `json:"name"`
+	CreatedBy          int                `json:"created_by,omitempty"`
+	UpdatedBy          int                `json:"updated_by,omitempty"`
+	CreatedAt          int64              `json:"created_at,omitempty"`
+	UpdatedAt          int64              `json:"updated_at,omitempty"`
+	IsDeleted          bool               `json:"is_deleted,omitempty"`
+	CustomFieldsValues []CustomFieldValue `json:"custom_fields_values,omitempty"`
+	AccountID          int                `json:"account_id,omitempty"`
+	Metadata           *LinkMetadata      `json:"metadata,omitempty"` // quantity and price of an element linked to an entity
+	Links              *Links             `json:"_links,omitempty"`
+}
+
+// CatalogElementsResponse represents the API response for catalog elements list
+type CatalogElementsResponse struct {
+	Embedded struct {
+		Elements []CatalogElement `json:"elements"`
+	} `json:"_embedded"`
+	Links Links `json:"_links"`
+	Page  int   `json:"_page,omitempty"`
+}
+
 // CatalogsService handles communication with catalog-related methods
 type CatalogsService struct {
 	client *Client
@@ -31,14 +60,91 @@ type CatalogsResponse struct {
 		Catalogs []Catalog `json:"catalogs"`
 	} `json:"_embedded"`
 	Links Links `json:"_links"`
+	Page  int   `json:"_page,omitempty"`
+}
+
+// CatalogsFilter represents filter options for listing catalogs
+type CatalogsFilter struct {
+	Limit int
+	Page  int
+	With  string
 }
 
 // List retrieves a list of catalogs
-func (s *CatalogsService) List(ctx context.Context) ([]Catalog, error) {
-	var resp CatalogsResponse
-	if err := s.client.GetJSON(ctx, "/catalogs", &resp); err != nil {
+func (s *CatalogsService) List(ctx context.Context, filter *CatalogsFilter) ([]Catalog, error) {
+	resp, err := s.ListWithResponse(ctx, filter)
+	if err != nil {
 		return nil, err
 	}
 
 	return resp.Embedded.Catalogs, nil
 }
+
+// ListWithResponse retrieves a list of catalogs with pagination links
+func (s *CatalogsService) ListWithResponse(ctx context.Context, filter *CatalogsFilter) (*CatalogsResponse, error) {
+	path := "/catalogs"
+
+	if filter != nil {
+		path += "?"
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		if filter.With != "" {
+			path += fmt.Sprintf("with=%s&", filter.With)
+		}
+	}
+
+	var resp CatalogsResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// FindElement searches catalog elements by name or by any field value,
+// e.g. a SKU stored in a custom field
+func (s *CatalogsService) FindElement(ctx context.Context, catalogID int, query string) ([]CatalogElement, error) {
+	if query == "" {
+		return nil, fmt.Errorf("search query is required")
+	}
+
+	path := fmt.Sprintf("/catalogs/%d/elements?query=%s", catalogID, url.QueryEscape(query))
+
+	var resp CatalogElementsResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Elements, nil
+}
+
+// FindElementByField returns catalog elements whose custom field fieldID
+// has exactly the given value. The API only supports full-text search, so
+// the search results are narrowed down on the client.
+func (s *CatalogsService) FindElementByField(ctx context.Context, catalogID int, fieldID int, value string) ([]CatalogElement, error) {
+	elements, err := s.FindElement(ctx, catalogID, value)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []CatalogElement
+	for _, element := range elements {
+		for _, field := range element.CustomFieldsValues {
+			if field.FieldID != fieldID {
+				continue
+			}
+			for _, v := range field.Values {
+				if fmt.Sprint(v.Value) == value {
+					matched = append(matched, element)
+					break
+				}
+			}
+		}
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,111 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCatalogsService_FindElementByField(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/catalogs/7/elements" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+		if r.URL.Query().Get("query") != "SKU 42" {
+			t.Errorf("Expected query 'SKU 42', got '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"elements": [
+			{"id": 1, "name": "Widget", "custom_fields_values": [{"field_id": 100, "values": [{"value": "SKU 42"}]}]},
+			{"id": 2, "name": "Widget SKU 42 case", "custom_fields_values": [{"field_id": 100, "values": [{"value": "SKU 43"}]}]}
+		]}}`))
+	})
+
+	elements, err := client.Catalogs.FindElementByField(context.Background(), 7, 100, "SKU 42")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(elements) != 1 || elements[0].ID != 1 {
+		t.Errorf("Expected only element 1, got %+v", elements)
+	}
+}
+
+func TestCatalogElementsService_ListWithResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/catalogs/5/elements" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") != "2" || r.URL.Query().Get("limit") != "50" {
+			t.Errorf("Unexpected query '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"_page": 2,
+			"_links": {"next": {"href": "https://example.amocrm.ru/api/v4/catalogs/5/elements?page=3"}},
+			"_embedded": {"elements": [{"id": 1, "catalog_id": 5, "name": "Товар"}]}
+		}`))
+	})
+
+	resp, err := client.CatalogElements.ListWithResponse(context.Background(), 5, &CatalogElementsFilter{Limit: 50, Page: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(resp.Embedded.Elements) != 1 || resp.Embedded.Elements[0].Name != "Товар" {
+		t.Errorf("Unexpected elements: %+v", resp.Embedded.Elements)
+	}
+	if !resp.Links.HasNext() {
+		t.Error("Expected a next page link")
+	}
+}
+
+func TestCatalogElementsService_Update(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v4/catalogs/5/elements" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"elements": [{"id": 1, "name": "Renamed"}]}}`))
+	})
+
+	ctx := context.Background()
+	if _, err := client.CatalogElements.Update(ctx, 5, &CatalogElement{Name: "No ID"}); err == nil {
+		t.Error("Expected error for update without ID")
+	}
+
+	element, err := client.CatalogElements.Update(ctx, 5, &CatalogElement{ID: 1, Name: "Renamed"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if element.Name != "Renamed" {
+		t.Errorf("Expected name 'Renamed', got '%s'", element.Name)
+	}
+}
+
+func TestCatalogsService_ListWithResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expected := "limit=10&page=2&with=elements_count&"
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"_page": 2,
+			"_links": {"next": {"href": "https://example.amocrm.ru/api/v4/catalogs?page=3"}},
+			"_embedded": {"catalogs": [{"id": 1, "name": "Товары", "type": "products"}]}
+		}`))
+	})
+
+	resp, err := client.Catalogs.ListWithResponse(context.Background(), &CatalogsFilter{Limit: 10, Page: 2, With: "elements_count"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(resp.Embedded.Catalogs) != 1 || resp.Page != 2 || !resp.Links.HasNext() {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
@@ -33,6 +33,7 @@
 package amocrm
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -58,19 +59,47 @@ const (
 	// DefaultTimeout is the default HTTP client timeout
 	DefaultTimeout = 30 * time.Second
 
+	// DefaultRetryMaxDelay caps the backoff between retries
+	DefaultRetryMaxDelay = 30 * time.Second
+
 	// APIVersion is the AmoCRM API version
 	APIVersion = "v4"
+
+	// Version is the library version reported in the User-Agent header
+	Version = "1.0"
+
+	// DefaultUserAgent is the User-Agent header sent by default
+	DefaultUserAgent = "amocrm-go/" + Version
+
+	// DefaultOAuthTokenPath is the default OAuth 2.0 token endpoint path
+	DefaultOAuthTokenPath = "/oauth2/access_token"
+
+	// DefaultOAuthAuthorizePath is the default OAuth 2.0 authorization page path
+	DefaultOAuthAuthorizePath = "/oauth"
 )
 
 // Client is the main AmoCRM API client
 type Client struct {
 	// HTTP client
-	httpClient *http.Client
+	httpClient       *http.Client
+	customHTTPClient bool
+	transport        *http.Transport
 
 	// Configuration
 	subdomain string
 	domain    string
 	baseURL   string
+	accountMu sync.RWMutex
+
+	// customBaseURL is set when baseURL was given with WithBaseURL and must
+	// survive subdomain changes
+	customBaseURL bool
+
+	// onSubdomainChange is called after the account subdomain was renamed
+	onSubdomainChange func(oldSubdomain, newSubdomain string)
+
+	// onTokenRefresh is called after a new OAuth2 token was obtained
+	onTokenRefresh func(domain string, token *Token)
 
 	// Authentication
 	authType       AuthType
@@ -80,23 +109,64 @@ type Client struct {
 	currentToken   *Token
 	tokenMu        sync.RWMutex
 
+	// OAuth 2.0 endpoints (paths or absolute URLs)
+	oauthTokenPath     string
+	oauthAuthorizePath string
+	requiredScopes     []string
+
 	// Rate limiting
-	rateLimiter *rate.Limiter
+	rateLimiter       *rate.Limiter
+	concurrency       chan struct{}
+	adaptiveRateLimit bool
+	baseRateLimit     rate.Limit // configured rate restored by adaptive limiting, set along with rateLimiter
+
+	// Retries of throttled and failed requests
+	maxRetries         int
+	retryBaseDelay     time.Duration
+	retryMaxDelay      time.Duration
+	retryNonIdempotent bool
+
+	// JSON codec
+	jsonMarshal   func(v interface{}) ([]byte, error)
+	jsonUnmarshal func(data []byte, v interface{}) error
+
+	// In-flight GET coalescing
+	coalesceGets bool
+	getFlights   flightGroup
+
+	// In-flight token refresh, shared by concurrent callers
+	refreshFlight flightGroup
+
+	// User-Agent header of requests
+	userAgent string
 
 	// Logging
-	logger *slog.Logger
-	debug  bool
+	logger       *slog.Logger
+	debug        bool
+	bodyLogLimit int
 
 	// API Services
-	Account   *AccountService
-	Contacts  *ContactsService
-	Companies *CompaniesService
-	Leads     *LeadsService
-	Tasks     *TasksService
-	Notes     *NotesService
-	Webhooks  *WebhooksService
-	Catalogs  *CatalogsService
-	Auth      *AuthService
+	Account         *AccountService
+	Contacts        *ContactsService
+	Companies       *CompaniesService
+	Leads           *LeadsService
+	Tasks           *TasksService
+	Notes           *NotesService
+	Webhooks        *WebhooksService
+	Catalogs        *CatalogsService
+	Events          *EventsService
+	Roles           *RolesService
+	Links           *LinksService
+	Unsorted        *UnsortedService
+	Pipelines       *PipelinesService
+	CustomFields    *CustomFieldsService
+	Tags            *TagsService
+	Users           *UsersService
+	Customers       *CustomersService
+	Invoices        *InvoicesService
+	CatalogElements *CatalogElementsService
+	Pagination      *PaginationService
+	Auth            *AuthService
 }
 
 // AuthType represents the type of authentication
@@ -122,11 +192,15 @@ type Token struct {
 	TokenType    string    `json:"token_type"`
 	ExpiresIn    int       `json:"expires_in"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	Scope        string    `json:"scope,omitempty"`
 }
 
+// timeNow returns the current time; overridden in tests
+var timeNow = time.Now
+
 // IsExpired checks if the token is expired
 func (t *Token) IsExpired() bool {
-	return time.Now().After(t.ExpiresAt)
+	return timeNow().After(t.ExpiresAt)
 }
 
 // ClientOption is a function that configures the Client
@@ -146,6 +220,17 @@ func WithDomain(domain string) ClientOption {
 	}
 }
 
+// WithBaseURL overrides the API base URL built from the subdomain and
+// domain, e.g. to point the client at a test server
+// ("http://127.0.0.1:8080/api/v4"). A custom base URL is kept when the
+// account subdomain changes; only the token storage key follows it.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+		c.customBaseURL = true
+	}
+}
+
 // WithPermanentToken sets permanent token authentication
 func WithPermanentToken(token string) ClientOption {
 	return func(c *Client) {
@@ -166,6 +251,49 @@ func WithOAuth2(clientID, clientSecret, redirectURI string) ClientOption {
 	}
 }
 
+// WithOAuthEndpoints overrides the OAuth 2.0 token and authorization
+// endpoints. Each value is either a path on the account domain or an
+// absolute URL (useful for proxies and mock OAuth servers). Empty values
+// keep the defaults.
+func WithOAuthEndpoints(tokenPath, authorizePath string) ClientOption {
+	return func(c *Client) {
+		if tokenPath != "" {
+			c.oauthTokenPath = tokenPath
+		}
+		if authorizePath != "" {
+			c.oauthAuthorizePath = authorizePath
+		}
+	}
+}
+
+// WithRequiredScopes makes ExchangeCode fail when the granted token lacks
+// any of the given scopes (e.g. "crm", "notifications")
+func WithRequiredScopes(scopes ...string) ClientOption {
+	return func(c *Client) {
+		c.requiredScopes = scopes
+	}
+}
+
+// WithSubdomainChangeCallback sets a function called when the client detects
+// that the account subdomain was renamed, so the app can persist the new one
+func WithSubdomainChangeCallback(fn func(oldSubdomain, newSubdomain string)) ClientOption {
+	return func(c *Client) {
+		c.onSubdomainChange = fn
+	}
+}
+
+// WithTokenRefreshCallback sets a function called with the account domain
+// and the new token after every successful token refresh and code
+// exchange, e.g. to publish rotated tokens to other services. It runs
+// synchronously on the goroutine that obtained the token, after the token
+// was stored and with no lock held; a slow callback delays that request, so
+// hand long work off to another goroutine.
+func WithTokenRefreshCallback(fn func(domain string, token *Token)) ClientOption {
+	return func(c *Client) {
+		c.onTokenRefresh = fn
+	}
+}
+
 // WithTokenStorage sets the token storage implementation
 func WithTokenStorage(storage TokenStorage) ClientOption {
 	return func(c *Client) {
@@ -177,13 +305,133 @@ func WithTokenStorage(storage TokenStorage) ClientOption {
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = httpClient
+		c.customHTTPClient = true
+	}
+}
+
+// WithTransportTuning configures connection pooling of the default HTTP
+// client. A client talks to a single host (the account subdomain), so
+// maxIdleConnsPerHost is what limits connection reuse: the net/http
+// default of 2 makes bursty batch workloads reopen connections, and it
+// should be close to the expected concurrency (see WithMaxConcurrency).
+// idleTimeout closes pooled connections unused for that long.
+//
+// The option is mutually exclusive with WithHTTPClient and is ignored when
+// a custom HTTP client is set; tune that client's transport instead.
+func WithTransportTuning(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) ClientOption {
+	return func(c *Client) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		transport.IdleConnTimeout = idleTimeout
+		c.transport = transport
 	}
 }
 
-// WithRateLimit sets the rate limit (requests per second)
+// WithRateLimit sets the rate limit (requests per second). Requests are
+// spaced evenly, without bursts; see WithRateLimitBurst.
 func WithRateLimit(rps int) ClientOption {
+	return WithRateLimitBurst(rps, 1)
+}
+
+// WithRateLimitBurst sets the rate limit (requests per second) and lets up
+// to burst requests start at once when the client was idle. A burst below
+// 1 is treated as 1.
+func WithRateLimitBurst(rps, burst int) ClientOption {
+	return func(c *Client) {
+		if burst < 1 {
+			burst = 1
+		}
+		c.rateLimiter = rate.NewLimiter(rate.Limit(rps), burst)
+		c.baseRateLimit = rate.Limit(rps)
+	}
+}
+
+// WithRequestCoalescing makes concurrent identical GET requests share one
+// HTTP call. Every caller decodes its own copy of the response. The shared
+// call is detached from the callers' cancellation and bounded by the HTTP
+// client timeout; each caller stops waiting when its own context is done.
+// Calls whose context carries WithRequestHeaders or WithResponseMeta are
+// never shared.
+func WithRequestCoalescing(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.coalesceGets = enabled
+	}
+}
+
+// WithJSONCodec replaces encoding/json for request and response bodies,
+// e.g. with a faster drop-in library. Nil functions keep the default.
+func WithJSONCodec(marshal func(v interface{}) ([]byte, error), unmarshal func(data []byte, v interface{}) error) ClientOption {
+	return func(c *Client) {
+		if marshal != nil {
+			c.jsonMarshal = marshal
+		}
+		if unmarshal != nil {
+			c.jsonUnmarshal = unmarshal
+		}
+	}
+}
+
+// WithMaxConcurrency caps the number of requests in flight at once. It
+// complements the rate limiter: the limiter spaces out request starts,
+// while this cap bounds open connections when responses are slow. A slot
+// is taken before waiting for the limiter and released once the response
+// body is closed. Zero or negative n disables the cap.
+func WithMaxConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.concurrency = make(chan struct{}, n)
+		} else {
+			c.concurrency = nil
+		}
+	}
+}
+
+// WithRetry retries requests that fail with 429 or 5xx up to maxRetries
+// times. The delay honors the Retry-After header when present and falls
+// back to exponential backoff with full jitter starting at baseDelay and
+// capped at DefaultRetryMaxDelay (see WithRetryBackoff). A Retry-After
+// longer than the maximum delay is not waited for: the error is returned
+// instead. Only idempotent methods are retried unless
+// WithRetryNonIdempotent is set.
+func WithRetry(maxRetries int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBaseDelay = baseDelay
+	}
+}
+
+// WithRetryBackoff sets the base and maximum delay of the retry backoff.
+// Retry n (starting at 0) waits a uniformly random duration between 0 and
+// min(max, base*2^n) ("full jitter"), so clients throttled at the same
+// moment spread their retries instead of retrying together. max also bounds
+// how long a Retry-After header is honored; 0 disables that bound.
+func WithRetryBackoff(base, max time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryBaseDelay = base
+		c.retryMaxDelay = max
+	}
+}
+
+// WithRetryNonIdempotent allows WithRetry to retry POST and PATCH
+// requests. A retried create may be applied twice if the first attempt
+// reached AmoCRM before failing.
+func WithRetryNonIdempotent(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.retryNonIdempotent = enabled
+	}
+}
+
+// WithUserAgent identifies the application in the User-Agent header. The
+// library version is appended, e.g. "my-app/1.2 amocrm-go/1.0", so AmoCRM
+// support can still see the SDK.
+func WithUserAgent(ua string) ClientOption {
 	return func(c *Client) {
-		c.rateLimiter = rate.NewLimiter(rate.Limit(rps), 1)
+		if ua == "" {
+			c.userAgent = DefaultUserAgent
+		} else {
+			c.userAgent = ua + " " + DefaultUserAgent
+		}
 	}
 }
 
@@ -208,15 +456,34 @@ func WithDebug(debug bool) ClientOption {
 	}
 }
 
+// WithBodyLogging sets how many bytes of request and response bodies are
+// logged in debug mode (see WithDebug); the default is
+// DefaultBodyLogLimit. Zero or negative maxBytes logs full bodies.
+func WithBodyLogging(maxBytes int) ClientOption {
+	return func(c *Client) {
+		if maxBytes <= 0 {
+			maxBytes = -1
+		}
+		c.bodyLogLimit = maxBytes
+	}
+}
+
 // NewClient creates a new AmoCRM API client
 func NewClient(opts ...ClientOption) *Client {
 	client := &Client{
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
-		domain:      DefaultDomain,
-		rateLimiter: rate.NewLimiter(rate.Limit(DefaultRateLimit), 1),
-		logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		domain:             DefaultDomain,
+		oauthTokenPath:     DefaultOAuthTokenPath,
+		oauthAuthorizePath: DefaultOAuthAuthorizePath,
+		rateLimiter:        rate.NewLimiter(rate.Limit(DefaultRateLimit), 1),
+		baseRateLimit:      rate.Limit(DefaultRateLimit),
+		retryMaxDelay:      DefaultRetryMaxDelay,
+		userAgent:          DefaultUserAgent,
+		bodyLogLimit:       DefaultBodyLogLimit,
+
+		logger: slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	// Apply options
@@ -229,8 +496,14 @@ func NewClient(opts ...ClientOption) *Client {
 		panic("subdomain is required")
 	}
 
+	if client.transport != nil && !client.customHTTPClient {
+		client.httpClient.Transport = client.transport
+	}
+
 	// Build base URL
-	client.baseURL = fmt.Sprintf("https://%s.%s/api/%s", client.subdomain, client.domain, APIVersion)
+	if client.baseURL == "" {
+		client.baseURL = fmt.Sprintf("https://%s.%s/api/%s", client.subdomain, client.domain, APIVersion)
+	}
 
 	// Initialize services
 	client.Account = &AccountService{client: client}
@@ -241,12 +514,23 @@ func NewClient(opts ...ClientOption) *Client {
 	client.Notes = &NotesService{client: client}
 	client.Webhooks = &WebhooksService{client: client}
 	client.Catalogs = &CatalogsService{client: client}
+	client.Events = &EventsService{client: client}
+	client.Roles = &RolesService{client: client}
+	client.Links = &LinksService{client: client}
+	client.Unsorted = &UnsortedService{client: client}
+	client.Pipelines = &PipelinesService{client: client}
+	client.CustomFields = &CustomFieldsService{client: client}
+	client.Tags = &TagsService{client: client}
+	client.Users = &UsersService{client: client}
+	client.Customers = &CustomersService{client: client}
+	client.Invoices = &InvoicesService{client: client}
+	client.CatalogElements = &CatalogElementsService{client: client}
+	client.Pagination = &PaginationService{client: client}
 	client.Auth = &AuthService{client: client}
 
 	// Load token if using OAuth2
 	if client.authType == AuthTypeOAuth2 && client.tokenStorage != nil {
-		domain := fmt.Sprintf("%s.%s", client.subdomain, client.domain)
-		token, err := client.tokenStorage.Load(context.Background(), domain)
+		token, err := client.tokenStorage.Load(context.Background(), client.accountDomain())
 		if err == nil && token != nil {
 			client.currentToken = token
 		}
@@ -255,77 +539,164 @@ func NewClient(opts ...ClientOption) *Client {
 	return client
 }
 
-// do executes an HTTP request with rate limiting and authentication
-func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+// Content types of request bodies
+const (
+	contentTypeJSON = "application/json"
+	contentTypeForm = "application/x-www-form-urlencoded"
+)
+
+// do executes an HTTP request with rate limiting and authentication.
+// The body is passed as bytes so the request can be safely re-sent; it is
+// sent with the given content type (JSON when empty).
+func (c *Client) do(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, error) {
+	sentToken := c.token()
+	resp, unauthorized, err := c.sendWithRetry(ctx, method, path, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle 401 Unauthorized - try to refresh token
+	if unauthorized {
+		if err := c.refreshToken(ctx, sentToken); err != nil {
+			return nil, fmt.Errorf("token refresh failed: %w", err)
+		}
+		// Retry request with new token
+		return c.do(ctx, method, path, contentType, body)
+	}
+
+	return resp, nil
+}
+
+// send performs a single HTTP request. It reports an OAuth2 401 response
+// as unauthorized (with the response already closed) so do can refresh
+// the token and retry.
+func (c *Client) send(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, bool, error) {
+	// Limit concurrent requests; the slot is held until the body is closed
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resp, unauthorized, err := c.roundTrip(ctx, method, path, contentType, body)
+	if err != nil || unauthorized {
+		release()
+		return nil, unauthorized, err
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, false, nil
+}
+
+// roundTrip waits for the rate limiter, sends the request and turns error
+// statuses into APIError
+func (c *Client) roundTrip(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, bool, error) {
 	// Wait for rate limiter
 	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limiter error: %w", err)
+		return nil, false, fmt.Errorf("rate limiter error: %w", err)
 	}
 
 	// Build URL
-	u, err := url.Parse(c.baseURL + path)
+	u, err := url.Parse(c.apiBaseURL() + path)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
+		return nil, false, fmt.Errorf("invalid URL: %w", err)
 	}
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "amocrm-go/1.0")
+	if contentType == "" {
+		contentType = contentTypeJSON
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", c.userAgent)
+	applyRequestHeaders(ctx, req)
 
 	// Add authentication
 	if err := c.addAuth(ctx, req); err != nil {
-		return nil, fmt.Errorf("authentication error: %w", err)
+		return nil, false, fmt.Errorf("authentication error: %w", err)
 	}
 
 	// Log request if debug is enabled
 	if c.debug {
-		c.logger.Debug("API Request",
-			"method", method,
-			"url", u.String(),
-		)
+		c.logRequest(req, contentType, body)
 	}
 
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, false, fmt.Errorf("request failed: %w", err)
+	}
+
+	recordResponseMeta(ctx, resp)
+
+	if c.adaptiveRateLimit {
+		c.adaptRateLimit(resp.Header)
+	}
+
+	// A renamed account redirects to its new subdomain
+	if resp.Request != nil && resp.Request.URL.Host != u.Host {
+		if sub, ok := strings.CutSuffix(resp.Request.URL.Hostname(), "."+c.domain); ok {
+			c.updateSubdomain(ctx, sub)
+		}
 	}
 
 	// Log response if debug is enabled
 	if c.debug {
-		c.logger.Debug("API Response",
-			"status", resp.StatusCode,
-			"url", u.String(),
-		)
+		c.logResponse(resp, u.String())
 	}
 
-	// Handle 401 Unauthorized - try to refresh token
 	if resp.StatusCode == http.StatusUnauthorized && c.authType == AuthTypeOAuth2 {
 		resp.Body.Close()
-		if err := c.refreshToken(ctx); err != nil {
-			return nil, fmt.Errorf("token refresh failed: %w", err)
-		}
-		// Retry request with new token
-		return c.do(ctx, method, path, body)
+		return nil, true, nil
 	}
 
 	// Check for API errors
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(bodyBytes),
-		}
+		return nil, false, newAPIError(resp, bodyBytes)
 	}
 
-	return resp, nil
+	return resp, false, nil
+}
+
+// acquireSlot takes a slot of the concurrency limit, if one is configured.
+// The returned function releases it and is safe to call more than once.
+func (c *Client) acquireSlot(ctx context.Context) (func(), error) {
+	if c.concurrency == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.concurrency <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("concurrency limit wait: %w", ctx.Err())
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-c.concurrency })
+	}, nil
+}
+
+// releaseOnClose releases a concurrency slot when the response body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+	return err
 }
 
 // addAuth adds authentication to the request
@@ -336,23 +707,17 @@ func (c *Client) addAuth(ctx context.Context, req *http.Request) error {
 		return nil
 
 	case AuthTypeOAuth2:
-		c.tokenMu.RLock()
-		token := c.currentToken
-		c.tokenMu.RUnlock()
-
+		token := c.token()
 		if token == nil {
 			return fmt.Errorf("no OAuth2 token available")
 		}
 
-		// Check if token is expired
+		// Refresh an expired token; no lock is held while refreshing
 		if token.IsExpired() {
-			c.tokenMu.RUnlock()
-			if err := c.refreshToken(ctx); err != nil {
+			if err := c.refreshToken(ctx, token); err != nil {
 				return err
 			}
-			c.tokenMu.RLock()
-			token = c.currentToken
-			c.tokenMu.RUnlock()
+			token = c.token()
 		}
 
 		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
@@ -363,12 +728,38 @@ func (c *Client) addAuth(ctx context.Context, req *http.Request) error {
 	}
 }
 
-// refreshToken refreshes the OAuth2 token
-func (c *Client) refreshToken(ctx context.Context) error {
-	c.tokenMu.Lock()
-	defer c.tokenMu.Unlock()
+// token returns the current OAuth2 token
+func (c *Client) token() *Token {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.currentToken
+}
+
+// refreshToken refreshes the OAuth2 token. AmoCRM refresh tokens are
+// single-use, so concurrent callers share one refresh instead of racing
+// to rotate the token. stale is the token the caller found expired or
+// rejected; when it was replaced in the meantime, the refresh is skipped.
+// A nil stale token always refreshes.
+func (c *Client) refreshToken(ctx context.Context, stale *Token) error {
+	_, err := c.refreshFlight.do(ctx, "refresh", func() (interface{}, error) {
+		if current := c.token(); stale != nil && current != nil && current != stale {
+			return nil, nil
+		}
+
+		// The rotation is shared by every waiting caller, so it must not
+		// be cancelled with the first one
+		ctx, cancel := c.detachedContext(ctx)
+		defer cancel()
+		return nil, c.rotateToken(ctx)
+	})
+	return err
+}
 
-	if c.currentToken == nil || c.currentToken.RefreshToken == "" {
+// rotateToken exchanges the refresh token for a new token, stores it and
+// saves it to the token storage. No lock is held during the request.
+func (c *Client) rotateToken(ctx context.Context) error {
+	current := c.token()
+	if current == nil || current.RefreshToken == "" {
 		return fmt.Errorf("no refresh token available")
 	}
 
@@ -377,105 +768,317 @@ func (c *Client) refreshToken(ctx context.Context) error {
 	data.Set("client_id", c.oauth2Config.ClientID)
 	data.Set("client_secret", c.oauth2Config.ClientSecret)
 	data.Set("grant_type", "refresh_token")
-	data.Set("refresh_token", c.currentToken.RefreshToken)
+	data.Set("refresh_token", current.RefreshToken)
 	data.Set("redirect_uri", c.oauth2Config.RedirectURI)
 
-	tokenURL := fmt.Sprintf("https://%s.%s/oauth2/access_token", c.subdomain, c.domain)
-	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(data.Encode()))
+	token, err := c.requestToken(ctx, data)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.tokenMu.Lock()
+	c.currentToken = token
+	c.tokenMu.Unlock()
+
+	// Save token
+	if c.tokenStorage != nil {
+		if err := c.tokenStorage.Save(ctx, c.accountDomain(), token); err != nil {
+			c.logger.Warn("Failed to save token", "error", err)
+		}
+	}
+
+	c.notifyTokenRefresh(token)
+	return nil
+}
+
+// notifyTokenRefresh passes a new token to the refresh callback, if set
+func (c *Client) notifyTokenRefresh(token *Token) {
+	if c.onTokenRefresh != nil {
+		c.onTokenRefresh(c.accountDomain(), token)
+	}
+}
+
+// requestToken posts a grant to the OAuth 2.0 token endpoint and returns
+// the issued token with ExpiresAt set. The request waits for the rate
+// limiter and is bounded by the HTTP client timeout and ctx; it is never
+// logged, as its body carries the client secret.
+func (c *Client) requestToken(ctx context.Context, data url.Values) (*Token, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.oauthURL(c.oauthTokenPath), strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentTypeForm)
+	req.Header.Set("User-Agent", c.userAgent)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("token refresh failed: %s", string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes)
 	}
 
 	var token Token
-	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
-		return err
+	if err := c.decodeJSON(resp.Body, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode token: %w", err)
+	}
+
+	token.ExpiresAt = timeNow().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return &token, nil
+}
+
+// oauthURL resolves an OAuth endpoint path against the account domain
+func (c *Client) oauthURL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
 	}
+	return fmt.Sprintf("https://%s%s", c.accountDomain(), path)
+}
 
-	token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
-	c.currentToken = &token
+// apiBaseURL returns the API base URL
+func (c *Client) apiBaseURL() string {
+	c.accountMu.RLock()
+	defer c.accountMu.RUnlock()
+	return c.baseURL
+}
 
-	// Save token
-	if c.tokenStorage != nil {
-		domain := fmt.Sprintf("%s.%s", c.subdomain, c.domain)
-		if err := c.tokenStorage.Save(ctx, domain, &token); err != nil {
-			c.logger.Warn("Failed to save token", "error", err)
+// accountDomain returns the full account domain, also used as the token
+// storage key (e.g. "testsubdomain.amocrm.ru")
+func (c *Client) accountDomain() string {
+	c.accountMu.RLock()
+	defer c.accountMu.RUnlock()
+	return fmt.Sprintf("%s.%s", c.subdomain, c.domain)
+}
+
+// updateSubdomain switches the client to a renamed account subdomain,
+// re-saves the token under the new domain and notifies the callback. A
+// base URL set with WithBaseURL is left untouched.
+func (c *Client) updateSubdomain(ctx context.Context, subdomain string) {
+	if subdomain == "" {
+		return
+	}
+
+	c.accountMu.Lock()
+	oldSubdomain := c.subdomain
+	if subdomain == oldSubdomain {
+		c.accountMu.Unlock()
+		return
+	}
+	c.subdomain = subdomain
+	if !c.customBaseURL {
+		c.baseURL = fmt.Sprintf("https://%s.%s/api/%s", subdomain, c.domain, APIVersion)
+	}
+	c.accountMu.Unlock()
+
+	c.logger.Warn("Account subdomain changed", "old", oldSubdomain, "new", subdomain)
+
+	if c.authType == AuthTypeOAuth2 && c.tokenStorage != nil {
+		if token := c.token(); token != nil {
+			if err := c.tokenStorage.Save(ctx, c.accountDomain(), token); err != nil {
+				c.logger.Warn("Failed to save token", "error", err)
+			}
 		}
 	}
 
-	return nil
+	if c.onSubdomainChange != nil {
+		c.onSubdomainChange(oldSubdomain, subdomain)
+	}
 }
 
-// GetJSON performs a GET request and decodes JSON response
-func (c *Client) GetJSON(ctx context.Context, path string, result interface{}) error {
-	resp, err := c.do(ctx, "GET", path, nil)
-	if err != nil {
-		return err
+// marshalJSON encodes v with the configured codec
+func (c *Client) marshalJSON(v interface{}) ([]byte, error) {
+	if c.jsonMarshal != nil {
+		return c.jsonMarshal(v)
 	}
-	defer resp.Body.Close()
+	return json.Marshal(v)
+}
 
-	return json.NewDecoder(resp.Body).Decode(result)
+// unmarshalJSON decodes data with the configured codec
+func (c *Client) unmarshalJSON(data []byte, v interface{}) error {
+	if c.jsonUnmarshal != nil {
+		return c.jsonUnmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
 }
 
-// PostJSON performs a POST request with JSON body
-func (c *Client) PostJSON(ctx context.Context, path string, body interface{}, result interface{}) error {
-	jsonData, err := json.Marshal(body)
+// decodeJSON decodes a JSON response body with the configured codec
+func (c *Client) decodeJSON(r io.Reader, result interface{}) error {
+	data, err := io.ReadAll(r)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.do(ctx, "POST", path, strings.NewReader(string(jsonData)))
+	return c.unmarshalJSON(data, result)
+}
+
+// DoJSON performs a request through the full client pipeline (rate limiting,
+// authentication, token refresh, error handling), sends body as JSON when
+// it is not nil and decodes the response into result when it is not nil.
+// The returned response gives access to the status code and headers; its
+// body is already consumed and closed.
+func (c *Client) DoJSON(ctx context.Context, method, path string, body interface{}, result interface{}) (*http.Response, error) {
+	var jsonData []byte
+	if body != nil {
+		var err error
+		jsonData, err = c.marshalJSON(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.do(ctx, method, path, contentTypeJSON, jsonData)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if result != nil {
-		return json.NewDecoder(resp.Body).Decode(result)
+	// Empty lists are returned as 204 No Content
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
 	}
 
-	return nil
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	if len(data) == 0 {
+		return resp, nil
+	}
+
+	return resp, c.unmarshalJSON(data, result)
 }
 
-// PatchJSON performs a PATCH request with JSON body
-func (c *Client) PatchJSON(ctx context.Context, path string, body interface{}, result interface{}) error {
-	jsonData, err := json.Marshal(body)
-	if err != nil {
-		return err
+// GetJSON performs a GET request and decodes JSON response
+func (c *Client) GetJSON(ctx context.Context, path string, result interface{}) error {
+	_, err := c.getJSONHeader(ctx, path, result)
+	return err
+}
+
+// getJSONHeader performs a GET request, decodes the JSON response into
+// result and returns the response headers
+func (c *Client) getJSONHeader(ctx context.Context, path string, result interface{}) (http.Header, error) {
+	if c.coalesceGets && !perCallContext(ctx) {
+		return c.getJSONShared(ctx, path, result)
 	}
 
-	resp, err := c.do(ctx, "PATCH", path, strings.NewReader(string(jsonData)))
+	resp, err := c.DoJSON(ctx, "GET", path, nil, result)
+	if resp == nil {
+		return nil, err
+	}
+	return resp.Header, err
+}
+
+// sharedResponse is the result of a GET request shared by concurrent callers
+type sharedResponse struct {
+	body   []byte
+	header http.Header
+}
+
+// getJSONShared performs a GET request shared with concurrent identical
+// requests and decodes the shared response body into result. Every caller
+// gets its own copy of the response headers. The shared request runs on a
+// context detached from the callers, so one caller giving up does not fail
+// the others; each caller still stops waiting when its own ctx is done.
+func (c *Client) getJSONShared(ctx context.Context, path string, result interface{}) (http.Header, error) {
+	shared, err := c.getFlights.do(ctx, path, func() (interface{}, error) {
+		ctx, cancel := c.detachedContext(ctx)
+		defer cancel()
+
+		resp, err := c.do(ctx, "GET", path, contentTypeJSON, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return sharedResponse{body: body, header: resp.Header}, nil
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if result != nil {
-		return json.NewDecoder(resp.Body).Decode(result)
+	r := shared.(sharedResponse)
+	header := r.header.Clone()
+	if len(r.body) == 0 {
+		return header, nil
 	}
 
-	return nil
+	return header, c.unmarshalJSON(r.body, result)
+}
+
+// PostJSON performs a POST request with JSON body
+func (c *Client) PostJSON(ctx context.Context, path string, body interface{}, result interface{}) error {
+	_, err := c.DoJSON(ctx, "POST", path, body, result)
+	return err
+}
+
+// PatchJSON performs a PATCH request with JSON body
+func (c *Client) PatchJSON(ctx context.Context, path string, body interface{}, result interface{}) error {
+	_, err := c.DoJSON(ctx, "PATCH", path, body, result)
+	return err
 }
 
 // DeleteJSON performs a DELETE request
 func (c *Client) DeleteJSON(ctx context.Context, path string) error {
-	resp, err := c.do(ctx, "DELETE", path, nil)
+	_, err := c.DoJSON(ctx, "DELETE", path, nil, nil)
+	return err
+}
+
+// deleteBatch deletes entities in one DELETE /{entity} request with a body
+// of [{"id": ...}] and returns the number of deleted entities. A 204
+// response means every entity was deleted; a response listing the deleted
+// entities that omits some of the IDs yields a *PartialDeleteError.
+func (c *Client) deleteBatch(ctx context.Context, entityType EntityType, ids []int) (int, error) {
+	return c.deleteBatchAt(ctx, "/"+string(entityType), entityType, ids)
+}
+
+// deleteBatchAt is deleteBatch for an arbitrary collection path; key is
+// the _embedded key of the deleted items in the response
+func (c *Client) deleteBatchAt(ctx context.Context, path string, key EntityType, ids []int) (int, error) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	req := make([]item, len(ids))
+	for i, id := range ids {
+		req[i] = item{ID: id}
+	}
+
+	var resp struct {
+		Embedded map[string][]item `json:"_embedded"`
+	}
+	httpResp, err := c.DoJSON(ctx, "DELETE", path, req, &resp)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if httpResp.StatusCode == http.StatusNoContent || resp.Embedded == nil {
+		return len(ids), nil
 	}
-	defer resp.Body.Close()
 
-	return nil
+	deleted := make(map[int]bool)
+	for _, entity := range resp.Embedded[string(key)] {
+		deleted[entity.ID] = true
+	}
+
+	var failed []int
+	for _, id := range ids {
+		if !deleted[id] {
+			failed = append(failed, id)
+		}
+	}
+	if len(failed) > 0 {
+		return len(ids) - len(failed), &PartialDeleteError{EntityType: key, FailedIDs: failed}
+	}
+
+	return len(ids), nil
 }
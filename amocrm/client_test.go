@@ -1,12 +1,42 @@
 package amocrm
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
+// newTestClient returns a client pointed at an httptest server running handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithSubdomain("test"),
+		WithPermanentToken("test-token"),
+	)
+	client.baseURL = server.URL + "/api/v4"
+	client.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	return client
+}
+
 func TestNewClient(t *testing.T) {
 	client := NewClient(
 		WithSubdomain("test"),
@@ -45,6 +75,31 @@ func TestClientWithOAuth2(t *testing.T) {
 	}
 }
 
+func TestWithTransportTuning(t *testing.T) {
+	client := NewClient(
+		WithSubdomain("test"),
+		WithTransportTuning(100, 20, 30*time.Second),
+	)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.httpClient.Transport)
+	}
+	if transport.MaxIdleConns != 100 || transport.MaxIdleConnsPerHost != 20 || transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("Unexpected transport settings: %d, %d, %v", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	custom := &http.Client{}
+	client = NewClient(
+		WithSubdomain("test"),
+		WithHTTPClient(custom),
+		WithTransportTuning(100, 20, 30*time.Second),
+	)
+	if custom.Transport != nil {
+		t.Error("Transport tuning should not modify a custom HTTP client")
+	}
+}
+
 func TestAPIError(t *testing.T) {
 	err := &APIError{
 		StatusCode: 404,
@@ -57,6 +112,106 @@ func TestAPIError(t *testing.T) {
 	}
 }
 
+func TestAPIErrorValidationErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"validation-errors": [{
+				"request_id": "0",
+				"errors": [{"code": "NotSupportedChoice", "path": "custom_fields_values.0.field_id", "detail": "The value you selected is not a valid choice."}]
+			}],
+			"title": "Bad Request",
+			"type": "https://httpstatus.es/400",
+			"status": 400,
+			"detail": "Request validation failed"
+		}`))
+	})
+
+	_, err := client.Leads.Create(context.Background(), &Lead{Name: "Invalid"})
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+
+	expected := "API error (status 400): Bad Request: Request validation failed"
+	if apiErr.Error() != expected {
+		t.Errorf("Expected error message '%s', got '%s'", expected, apiErr.Error())
+	}
+	if len(apiErr.ValidationErrors) != 1 {
+		t.Fatalf("Expected 1 validation error, got %d", len(apiErr.ValidationErrors))
+	}
+	if v := apiErr.ValidationErrors[0]; v.Field != "custom_fields_values.0.field_id" || v.Code != "NotSupportedChoice" || v.RequestID != "0" {
+		t.Errorf("Unexpected validation error: %+v", v)
+	}
+	if !strings.Contains(apiErr.Message, "validation-errors") {
+		t.Error("Expected raw body to be kept in Message")
+	}
+}
+
+func TestIsFeatureUnavailable(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{&APIError{StatusCode: 402, Message: "Payment Required"}, true},
+		{&APIError{StatusCode: 403, Message: `{"title":"Forbidden","detail":"Feature not available on your tariff"}`}, true},
+		{&APIError{StatusCode: 403, Message: "Access denied"}, false},
+		{fmt.Errorf("wrapped: %w", &APIError{StatusCode: 402}), true},
+		{&APIError{StatusCode: 500, Message: "Internal Server Error"}, false},
+	}
+
+	for _, c := range cases {
+		if got := IsFeatureUnavailable(c.err); got != c.expected {
+			t.Errorf("Expected %v for %v, got %v", c.expected, c.err, got)
+		}
+	}
+}
+
+func TestErrorStatusHelpers(t *testing.T) {
+	notFound := fmt.Errorf("get lead: %w", &APIError{StatusCode: 404})
+
+	cases := []struct {
+		name  string
+		check func(error) bool
+		err   error
+		want  bool
+	}{
+		{"not found", IsNotFound, notFound, true},
+		{"not found other status", IsNotFound, &APIError{StatusCode: 500}, false},
+		{"unauthorized", IsUnauthorized, &APIError{StatusCode: 401}, true},
+		{"rate limited", IsRateLimited, &APIError{StatusCode: 429}, true},
+		{"validation 400", IsValidationError, &APIError{StatusCode: 400}, true},
+		{"validation errors", IsValidationError, &APIError{StatusCode: 422, ValidationErrors: []ValidationError{{Field: "name"}}}, true},
+		{"validation other status", IsValidationError, notFound, false},
+		{"nil", IsNotFound, nil, false},
+		{"plain error", IsRateLimited, fmt.Errorf("429"), false},
+	}
+
+	for _, c := range cases {
+		if got := c.check(c.err); got != c.want {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+}
+
+func TestAPIErrorIsSentinel(t *testing.T) {
+	err := fmt.Errorf("get lead: %w", &APIError{StatusCode: 404, Message: "Not Found"})
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("Expected a 404 error to match ErrNotFound")
+	}
+	if errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrFeatureUnavailable) {
+		t.Error("Expected a 404 error to match only ErrNotFound")
+	}
+	if !errors.Is(&APIError{StatusCode: 401}, ErrUnauthorized) || !errors.Is(&APIError{StatusCode: 429}, ErrRateLimited) {
+		t.Error("Expected 401 and 429 errors to match their sentinels")
+	}
+	if got := err.Error(); got != "get lead: API error (status 404): Not Found" {
+		t.Errorf("Unexpected error message '%s'", got)
+	}
+}
+
 func TestTokenIsExpired(t *testing.T) {
 	// Test expired token
 	expiredToken := &Token{
@@ -126,3 +281,593 @@ func TestContactsService_List(t *testing.T) {
 		t.Errorf("Expected contact name 'Test Contact', got '%s'", contacts[0].Name)
 	}
 }
+
+func TestWithResponseMeta(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	var meta ResponseMeta
+	ctx := WithResponseMeta(context.Background(), &meta)
+	if _, err := client.Contacts.List(ctx, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", meta.StatusCode)
+	}
+
+	if meta.Header.Get("X-Request-Id") != "abc" {
+		t.Errorf("Expected X-Request-Id 'abc', got '%s'", meta.Header.Get("X-Request-Id"))
+	}
+}
+
+func TestWithRequestHeaders(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Request-Id"); got != "req-1" {
+			t.Errorf("Expected X-Request-Id 'req-1', got '%s'", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "my-app/2.0" {
+			t.Errorf("Expected User-Agent 'my-app/2.0', got '%s'", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Expected Authorization to be kept, got '%s'", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-1")
+	header.Set("User-Agent", "my-app/2.0")
+	header.Set("Authorization", "Bearer other")
+
+	ctx := WithRequestHeaders(context.Background(), header)
+	if _, err := client.Contacts.List(ctx, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestWithOAuthEndpoints(t *testing.T) {
+	client := NewClient(
+		WithSubdomain("test"),
+		WithOAuth2("client-id", "client-secret", "https://example.com/callback"),
+	)
+
+	authURL, err := client.Auth.GetAuthorizationURL("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(authURL, "https://test.amocrm.ru/oauth?") {
+		t.Errorf("Unexpected default authorization URL '%s'", authURL)
+	}
+
+	client = NewClient(
+		WithSubdomain("test"),
+		WithOAuth2("client-id", "client-secret", "https://example.com/callback"),
+		WithOAuthEndpoints("http://127.0.0.1:9000/token", "/custom/authorize"),
+	)
+
+	if got := client.oauthURL(client.oauthTokenPath); got != "http://127.0.0.1:9000/token" {
+		t.Errorf("Expected absolute token URL, got '%s'", got)
+	}
+
+	authURL, _ = client.Auth.GetAuthorizationURL("", "")
+	if !strings.HasPrefix(authURL, "https://test.amocrm.ru/custom/authorize?") {
+		t.Errorf("Unexpected custom authorization URL '%s'", authURL)
+	}
+}
+
+func TestDoRetriesWithFullBody(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/access_token" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token": "new", "refresh_token": "r2", "expires_in": 86400}`))
+			return
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	client.authType = AuthTypeOAuth2
+	client.oauth2Config = &OAuth2Config{ClientID: "id", ClientSecret: "secret"}
+	client.oauthTokenPath = strings.TrimSuffix(client.baseURL, "/api/v4") + "/oauth2/access_token"
+	client.currentToken = &Token{AccessToken: "old", RefreshToken: "r1", ExpiresAt: timeNow().Add(time.Hour)}
+
+	if err := client.PostJSON(context.Background(), "/leads", map[string]string{"name": "Deal"}, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("Expected the retried request to carry the same body, got %q", bodies)
+	}
+}
+
+func TestAddAuthConcurrentExpiredToken(t *testing.T) {
+	var refreshes int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/access_token" {
+			n := atomic.AddInt32(&refreshes, 1)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"access_token": "new", "refresh_token": "r%d", "expires_in": 86400}`, n+1)
+			return
+		}
+
+		if got := r.Header.Get("Authorization"); got != "Bearer new" {
+			t.Errorf("Expected the refreshed token, got '%s'", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.authType = AuthTypeOAuth2
+	client.oauth2Config = &OAuth2Config{ClientID: "id", ClientSecret: "secret"}
+	client.oauthTokenPath = strings.TrimSuffix(client.baseURL, "/api/v4") + "/oauth2/access_token"
+	client.currentToken = &Token{AccessToken: "old", RefreshToken: "r1", ExpiresAt: timeNow().Add(-time.Minute)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Leads.List(context.Background(), nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Errorf("Expected the expired token to be refreshed once, got %d refreshes", got)
+	}
+}
+
+func TestRefreshTokenSingleFlight(t *testing.T) {
+	var refreshes int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/access_token" {
+			atomic.AddInt32(&refreshes, 1)
+			r.ParseForm()
+			if got := r.PostForm.Get("refresh_token"); got != "r1" {
+				t.Errorf("Expected the single-use refresh token 'r1', got '%s'", got)
+			}
+			time.Sleep(50 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token": "new", "refresh_token": "r2", "expires_in": 86400}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.authType = AuthTypeOAuth2
+	client.oauth2Config = &OAuth2Config{ClientID: "id", ClientSecret: "secret"}
+	client.oauthTokenPath = strings.TrimSuffix(client.baseURL, "/api/v4") + "/oauth2/access_token"
+	client.currentToken = &Token{AccessToken: "old", RefreshToken: "r1", ExpiresAt: timeNow().Add(time.Hour)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Leads.List(context.Background(), nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Errorf("Expected 1 token refresh, got %d", got)
+	}
+}
+
+func TestRefreshTokenOutlivesFirstCaller(t *testing.T) {
+	var refreshes int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/access_token" {
+			if atomic.AddInt32(&refreshes, 1) == 1 {
+				close(started)
+			}
+			<-release
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token": "new", "refresh_token": "r2", "expires_in": 86400}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.authType = AuthTypeOAuth2
+	client.oauth2Config = &OAuth2Config{ClientID: "id", ClientSecret: "secret"}
+	client.oauthTokenPath = strings.TrimSuffix(client.baseURL, "/api/v4") + "/oauth2/access_token"
+	client.currentToken = &Token{AccessToken: "old", RefreshToken: "r1", ExpiresAt: timeNow().Add(time.Hour)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := client.Leads.List(ctx, nil)
+		errc <- err
+	}()
+
+	<-started
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the cancelled caller to fail, got %v", err)
+	}
+	close(release)
+
+	// The rotation finishes for the other callers although the caller
+	// that started it is gone
+	deadline := time.Now().Add(time.Second)
+	for client.token().AccessToken != "new" {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the refreshed token to be stored")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, err := client.Leads.List(context.Background(), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Errorf("Expected 1 token refresh, got %d", got)
+	}
+}
+
+func BenchmarkRequestBody(b *testing.B) {
+	leads := make([]Lead, 250)
+	for i := range leads {
+		leads[i] = Lead{Name: "Benchmark lead", Price: i}
+	}
+	jsonData, _ := json.Marshal(map[string][]Lead{"leads": leads})
+
+	b.Run("strings.NewReader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			io.Copy(io.Discard, strings.NewReader(string(jsonData)))
+		}
+	})
+
+	b.Run("bytes.NewReader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			io.Copy(io.Discard, bytes.NewReader(jsonData))
+		}
+	})
+}
+
+func TestWithRequestCoalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Acme"}`))
+	})
+	client.coalesceGets = true
+
+	const callers = 5
+	accounts := make([]*Account, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var account Account
+			if err := client.GetJSON(context.Background(), "/account", &account); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			accounts[i] = &account
+		}(i)
+	}
+
+	// Let all callers join the in-flight request before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 HTTP call, got %d", got)
+	}
+
+	accounts[0].Name = "Changed"
+	for _, account := range accounts[1:] {
+		if account.Name != "Acme" {
+			t.Errorf("Callers should get independent copies, got '%s'", account.Name)
+		}
+	}
+}
+
+func TestWithRequestCoalescingPerCallContext(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("X-Request-Id", r.Header.Get("X-Request-Id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.coalesceGets = true
+
+	metas := make([]ResponseMeta, 2)
+	var wg sync.WaitGroup
+	for i := range metas {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			header := http.Header{}
+			header.Set("X-Request-Id", fmt.Sprint("req-", i))
+			ctx := WithResponseMeta(WithRequestHeaders(context.Background(), header), &metas[i])
+			if err := client.GetJSON(ctx, "/account", nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected per-call contexts to bypass coalescing, got %d HTTP calls", got)
+	}
+	for i, meta := range metas {
+		if got := meta.Header.Get("X-Request-Id"); got != fmt.Sprint("req-", i) {
+			t.Errorf("Caller %d: expected its own request ID, got '%s'", i, got)
+		}
+	}
+}
+
+func TestWithRequestCoalescingCancellation(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Acme"}`))
+	})
+	client.coalesceGets = true
+
+	// The first caller starts the shared request and gives up
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		firstErr <- client.GetJSON(first, "/account", &Account{})
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	secondErr := make(chan error, 1)
+	var second Account
+	go func() {
+		secondErr <- client.GetJSON(context.Background(), "/account", &second)
+	}()
+
+	// A waiter with a short deadline stops waiting on its own
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	if err := client.GetJSON(short, "/account", &Account{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the waiter's deadline to be honored, got %v", err)
+	}
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the first caller to be cancelled, got %v", err)
+	}
+
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Fatalf("Expected the second caller to succeed, got %v", err)
+	}
+	if second.Name != "Acme" {
+		t.Errorf("Expected the shared response, got %+v", second)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 HTTP call, got %d", got)
+	}
+}
+
+func TestWithJSONCodec(t *testing.T) {
+	var marshals, unmarshals int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"leads": [{"id": 1, "name": "Deal"}]}}`))
+	})
+	WithJSONCodec(
+		func(v interface{}) ([]byte, error) {
+			marshals++
+			return json.Marshal(v)
+		},
+		func(data []byte, v interface{}) error {
+			unmarshals++
+			return json.Unmarshal(data, v)
+		},
+	)(client)
+
+	ctx := context.Background()
+	if _, err := client.Leads.Create(ctx, &Lead{Name: "Deal"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Leads.List(ctx, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if marshals != 1 || unmarshals != 2 {
+		t.Errorf("Expected 1 marshal and 2 unmarshals, got %d and %d", marshals, unmarshals)
+	}
+}
+
+func TestDoJSON(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected PATCH, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{"id": 5, "name": "Deal"}`))
+	})
+
+	var lead Lead
+	resp, err := client.DoJSON(context.Background(), http.MethodPatch, "/leads/5", map[string]string{"name": "Deal"}, &lead)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Request-Id") != "req-1" {
+		t.Errorf("Unexpected response metadata: %d %v", resp.StatusCode, resp.Header)
+	}
+
+	if lead.ID != 5 {
+		t.Errorf("Expected decoded lead 5, got %+v", lead)
+	}
+}
+
+func TestDoContentType(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); !strings.HasPrefix(got, "multipart/form-data") {
+			t.Errorf("Expected multipart content type, got '%s'", got)
+		}
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != "raw" {
+			t.Errorf("Unexpected body '%s'", b)
+		}
+	})
+
+	resp, err := client.do(context.Background(), http.MethodPost, "/upload", "multipart/form-data; boundary=x", []byte("raw"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestWithMaxConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	WithMaxConcurrency(2)(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Leads.List(context.Background(), nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", got)
+	}
+	if len(client.concurrency) != 0 {
+		t.Errorf("Expected all slots released, %d still held", len(client.concurrency))
+	}
+}
+
+func TestWithRateLimitBurst(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	WithRateLimitBurst(1, 5)(client)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Leads.List(context.Background(), nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected a burst of 5 requests without waiting, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&requests); got != 5 {
+		t.Errorf("Expected 5 requests, got %d", got)
+	}
+
+	// The burst is used up, so the next request has to wait for a token
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Leads.List(ctx, nil); err == nil {
+		t.Error("Expected the request after the burst to be rate limited")
+	}
+}
+
+func TestWithRateLimitDefaultBurst(t *testing.T) {
+	client := NewClient(WithSubdomain("test"), WithRateLimit(3))
+	if burst := client.rateLimiter.Burst(); burst != 1 {
+		t.Errorf("Expected burst 1, got %d", burst)
+	}
+
+	client = NewClient(WithSubdomain("test"))
+	if burst := client.rateLimiter.Burst(); burst != 1 {
+		t.Errorf("Expected default burst 1, got %d", burst)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+		want string
+	}{
+		{"default", nil, "amocrm-go/" + Version},
+		{"custom", []ClientOption{WithUserAgent("my-app/1.2")}, "my-app/1.2 amocrm-go/" + Version},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("User-Agent"); got != tt.want {
+					t.Errorf("Expected User-Agent '%s', got '%s'", tt.want, got)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			opts := append([]ClientOption{
+				WithSubdomain("test"),
+				WithPermanentToken("test-token"),
+				WithBaseURL(server.URL + "/api/v4"),
+			}, tt.opts...)
+			client := NewClient(opts...)
+
+			if _, err := client.Contacts.List(context.Background(), nil); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package amocrm
 import (
 	"context"
 	"fmt"
+	"net/http"
 )
 
 // Company represents an AmoCRM company
@@ -22,6 +23,24 @@ type Company struct {
 	Embedded           *Embedded          `json:"_embedded,omitempty"`
 }
 
+// CustomFieldValue returns the first value of a custom field of the company
+// as a string
+func (c *Company) CustomFieldValue(fieldID int) (string, bool) {
+	return customFields(c.CustomFieldsValues).first(fieldID)
+}
+
+// CustomFieldValues returns all values of a custom field of the company
+// as strings, e.g. every phone number
+func (c *Company) CustomFieldValues(fieldID int) []string {
+	return customFields(c.CustomFieldsValues).all(fieldID)
+}
+
+// FieldByCode returns a custom field of the company by its code (e.g.
+// PHONE), or nil if the company has no value for it
+func (c *Company) FieldByCode(code string) *CustomFieldValue {
+	return customFields(c.CustomFieldsValues).byCode(code)
+}
+
 // CompaniesService handles communication with company-related methods
 type CompaniesService struct {
 	client *Client
@@ -34,25 +53,62 @@ type CompaniesResponse struct {
 	} `json:"_embedded"`
 	Links Links `json:"_links"`
 	Page  Page  `json:"_page,omitempty"`
+
+	// Header holds the HTTP response headers, e.g. X-RateLimit-Remaining
+	Header http.Header `json:"-"`
 }
 
 // CompaniesFilter represents filter options for listing companies
 type CompaniesFilter struct {
-	Query string
-	Limit int
-	Page  int
-	With  string // comma-separated list: leads, customers, contacts, catalog_elements
-	Order string // created_at, updated_at, id
+	Query          string
+	Limit          int
+	Page           int
+	With           string // comma-separated list: leads, customers, contacts, catalog_elements
+	Order          string // created_at, updated_at, id
+	OrderDirection string // OrderAsc or OrderDesc for Order; asc when empty
+
+	// MaxPages caps the pages read by ListAll; DefaultListAllMaxPages when 0
+	MaxPages int
+
+	// IDs limits the list to the given IDs. List splits more than 250 IDs
+	// into several requests and merges the results, returning every
+	// matching entity: Limit and Page are ignored then. ListWithResponse
+	// sends them in one request, which AmoCRM rejects above 250.
+	IDs []int
+
+	// ResponsibleUserID limits the list to entities of the given users
+	ResponsibleUserID []int
 }
 
 // List retrieves a list of companies
 func (s *CompaniesService) List(ctx context.Context, filter *CompaniesFilter) ([]Company, error) {
+	if filter != nil && len(filter.IDs) > maxIDsPerRequest {
+		return listByIDChunks(filter.IDs, func(chunk []int) ([]Company, error) {
+			f := *filter
+			f.IDs = chunk
+			f.Limit = len(chunk)
+			f.Page = 1
+			return s.List(ctx, &f)
+		})
+	}
+
+	resp, err := s.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Companies, nil
+}
+
+// ListWithResponse retrieves a list of companies with pagination links and
+// the response headers
+func (s *CompaniesService) ListWithResponse(ctx context.Context, filter *CompaniesFilter) (*CompaniesResponse, error) {
 	path := "/companies"
 
 	if filter != nil {
 		path += "?"
 		if filter.Query != "" {
-			path += fmt.Sprintf("query=%s&", filter.Query)
+			path += searchQuery(filter.Query)
 		}
 		if filter.Limit > 0 {
 			path += fmt.Sprintf("limit=%d&", filter.Limit)
@@ -64,16 +120,20 @@ func (s *CompaniesService) List(ctx context.Context, filter *CompaniesFilter) ([
 			path += fmt.Sprintf("with=%s&", filter.With)
 		}
 		if filter.Order != "" {
-			path += fmt.Sprintf("order[%s]=asc&", filter.Order)
+			path += orderFilter([]OrderField{{Field: filter.Order, Direction: filter.OrderDirection}})
 		}
+		path += listFilter("id", filter.IDs)
+		path += listFilter("responsible_user_id", filter.ResponsibleUserID)
 	}
 
 	var resp CompaniesResponse
-	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+	header, err := s.client.getJSONHeader(ctx, path, &resp)
+	if err != nil {
 		return nil, err
 	}
+	resp.Header = header
 
-	return resp.Embedded.Companies, nil
+	return &resp, nil
 }
 
 // GetByID retrieves a company by ID
@@ -88,6 +148,29 @@ func (s *CompaniesService) GetByID(ctx context.Context, id int) (*Company, error
 	return &company, nil
 }
 
+// Contacts retrieves the contacts linked to a company. The company embeds
+// only contact IDs, so the contacts themselves are fetched with one more
+// request.
+func (s *CompaniesService) Contacts(ctx context.Context, companyID int) ([]Contact, error) {
+	path := fmt.Sprintf("/companies/%d?with=contacts", companyID)
+
+	var company Company
+	if err := s.client.GetJSON(ctx, path, &company); err != nil {
+		return nil, err
+	}
+
+	if company.Embedded == nil || len(company.Embedded.Contacts) == 0 {
+		return []Contact{}, nil
+	}
+
+	ids := make([]int, len(company.Embedded.Contacts))
+	for i, contact := range company.Embedded.Contacts {
+		ids[i] = contact.ID
+	}
+
+	return s.client.Contacts.GetByIDs(ctx, ids)
+}
+
 // Create creates a new company
 func (s *CompaniesService) Create(ctx context.Context, company *Company) (*Company, error) {
 	type request struct {
@@ -184,3 +267,35 @@ func (s *CompaniesService) UpdateBatch(ctx context.Context, companies []*Company
 
 	return resp.Embedded.Companies, nil
 }
+
+// Delete deletes a company by ID
+func (s *CompaniesService) Delete(ctx context.Context, id int) error {
+	if id == 0 {
+		return fmt.Errorf("company ID is required for delete")
+	}
+
+	path := fmt.Sprintf("/companies/%d", id)
+	return s.client.DeleteJSON(ctx, path)
+}
+
+// DeleteBatch deletes multiple companies in one request. If only some of
+// the companies were deleted, a *PartialDeleteError lists the failed IDs.
+func (s *CompaniesService) DeleteBatch(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("no company IDs to delete")
+	}
+	for i, id := range ids {
+		if id == 0 {
+			return fmt.Errorf("company ID is required for delete at index %d", i)
+		}
+	}
+
+	_, err := s.client.deleteBatch(ctx, EntityTypeCompany, ids)
+	return err
+}
+
+// GetLinks retrieves the entities a company is linked to. The filter is
+// optional and may narrow the result to one entity type.
+func (s *CompaniesService) GetLinks(ctx context.Context, companyID int, filter *LinksFilter) ([]EntityLink, error) {
+	return s.client.getLinks(ctx, EntityTypeCompany, companyID, filter)
+}
@@ -0,0 +1,76 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCompaniesService_Contacts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v4/companies/1":
+			if r.URL.Query().Get("with") != "contacts" {
+				t.Errorf("Expected with=contacts, got '%s'", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"id": 1, "name": "Acme", "_embedded": {"contacts": [{"id": 20}, {"id": 21}]}}`))
+		case "/api/v4/companies/2":
+			w.Write([]byte(`{"id": 2, "name": "Empty", "_embedded": {"contacts": []}}`))
+		case "/api/v4/contacts":
+			if got := r.URL.Query()["filter[id][]"]; len(got) != 2 {
+				t.Errorf("Expected 2 contact IDs, got %v", got)
+			}
+			w.Write([]byte(`{"_embedded": {"contacts": [{"id": 20, "name": "Ivan"}, {"id": 21, "name": "Petr"}]}}`))
+		default:
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	contacts, err := client.Companies.Contacts(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(contacts) != 2 || contacts[0].Name != "Ivan" {
+		t.Errorf("Unexpected contacts: %+v", contacts)
+	}
+
+	contacts, err = client.Companies.Contacts(ctx, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if contacts == nil || len(contacts) != 0 {
+		t.Errorf("Expected empty contacts, got %+v", contacts)
+	}
+}
+
+func TestCompaniesService_DeleteBatch(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/companies" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if err := client.Companies.DeleteBatch(ctx, nil); err == nil {
+		t.Error("Expected error for empty IDs")
+	}
+	if err := client.Companies.DeleteBatch(ctx, []int{3, 0}); err == nil {
+		t.Error("Expected error for zero ID")
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests for invalid IDs, got %d", requests)
+	}
+
+	if err := client.Companies.DeleteBatch(ctx, []int{3, 4}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
@@ -2,7 +2,9 @@ package amocrm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 )
 
 // Contact represents an AmoCRM contact
@@ -20,10 +22,29 @@ type Contact struct {
 	ClosestTaskAt      int64              `json:"closest_task_at,omitempty"`
 	CustomFieldsValues []CustomFieldValue `json:"custom_fields_values,omitempty"`
 	AccountID          int                `json:"account_id,omitempty"`
+	IsMain             bool               `json:"is_main,omitempty"` // set on contacts embedded in a lead
 	Links              *Links             `json:"_links,omitempty"`
 	Embedded           *Embedded          `json:"_embedded,omitempty"`
 }
 
+// CustomFieldValue returns the first value of a custom field of the contact
+// as a string
+func (c *Contact) CustomFieldValue(fieldID int) (string, bool) {
+	return customFields(c.CustomFieldsValues).first(fieldID)
+}
+
+// CustomFieldValues returns all values of a custom field of the contact
+// as strings, e.g. every phone number
+func (c *Contact) CustomFieldValues(fieldID int) []string {
+	return customFields(c.CustomFieldsValues).all(fieldID)
+}
+
+// FieldByCode returns a custom field of the contact by its code (e.g.
+// PHONE), or nil if the contact has no value for it
+func (c *Contact) FieldByCode(code string) *CustomFieldValue {
+	return customFields(c.CustomFieldsValues).byCode(code)
+}
+
 // ContactsService handles communication with contact-related methods
 type ContactsService struct {
 	client *Client
@@ -36,31 +57,90 @@ type ContactsResponse struct {
 	} `json:"_embedded"`
 	Links Links `json:"_links"`
 	Page  Page  `json:"_page,omitempty"`
+
+	// Header holds the HTTP response headers, e.g. X-RateLimit-Remaining
+	Header http.Header `json:"-"`
 }
 
 // Page represents pagination information
 type Page struct {
-	Size  int `json:"size,omitempty"`
-	Count int `json:"count,omitempty"`
+	Number int `json:"-"`
+	Size   int `json:"size,omitempty"`
+	Count  int `json:"count,omitempty"`
+}
+
+// UnmarshalJSON accepts both the page number the API returns in lists
+// ("_page": 2) and the object form
+func (p *Page) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.Number); err == nil {
+		return nil
+	}
+
+	type page Page
+	return json.Unmarshal(data, (*page)(p))
 }
 
 // ContactsFilter represents filter options for listing contacts
 type ContactsFilter struct {
-	Query string
-	Limit int
-	Page  int
-	With  string // comma-separated list: leads, customers, catalog_elements
-	Order string // created_at, updated_at, id
+	Query          string
+	Limit          int
+	Page           int
+	With           string // comma-separated list: leads, customers, catalog_elements
+	Order          string // created_at, updated_at, id
+	OrderDirection string // OrderAsc or OrderDesc for Order; asc when empty
+
+	// MaxPages caps the pages read by ListAll; DefaultListAllMaxPages when 0
+	MaxPages int
+
+	// IDs limits the list to the given IDs. List splits more than 250 IDs
+	// into several requests and merges the results, returning every
+	// matching entity: Limit and Page are ignored then. ListWithResponse
+	// sends them in one request, which AmoCRM rejects above 250.
+	IDs []int
+
+	// ResponsibleUserID limits the list to entities of the given users
+	ResponsibleUserID []int
+
+	// CustomFields matches contacts by exact custom field values, keyed by
+	// field ID (e.g. a phone number). A contact matches any of the values
+	// of a field.
+	CustomFields map[int][]string
+
+	// Time range filters (keys: from, to)
+	CreatedAt     map[string]int64
+	UpdatedAt     map[string]int64
+	ClosestTaskAt map[string]int64
 }
 
 // List retrieves a list of contacts
 func (s *ContactsService) List(ctx context.Context, filter *ContactsFilter) ([]Contact, error) {
+	if filter != nil && len(filter.IDs) > maxIDsPerRequest {
+		return listByIDChunks(filter.IDs, func(chunk []int) ([]Contact, error) {
+			f := *filter
+			f.IDs = chunk
+			f.Limit = len(chunk)
+			f.Page = 1
+			return s.List(ctx, &f)
+		})
+	}
+
+	resp, err := s.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Contacts, nil
+}
+
+// ListWithResponse retrieves a list of contacts with pagination links and
+// the response headers
+func (s *ContactsService) ListWithResponse(ctx context.Context, filter *ContactsFilter) (*ContactsResponse, error) {
 	path := "/contacts"
 
 	if filter != nil {
 		path += "?"
 		if filter.Query != "" {
-			path += fmt.Sprintf("query=%s&", filter.Query)
+			path += searchQuery(filter.Query)
 		}
 		if filter.Limit > 0 {
 			path += fmt.Sprintf("limit=%d&", filter.Limit)
@@ -72,21 +152,39 @@ func (s *ContactsService) List(ctx context.Context, filter *ContactsFilter) ([]C
 			path += fmt.Sprintf("with=%s&", filter.With)
 		}
 		if filter.Order != "" {
-			path += fmt.Sprintf("order[%s]=asc&", filter.Order)
+			path += orderFilter([]OrderField{{Field: filter.Order, Direction: filter.OrderDirection}})
 		}
+		path += rangeFilter("created_at", filter.CreatedAt)
+		path += rangeFilter("updated_at", filter.UpdatedAt)
+		path += rangeFilter("closest_task_at", filter.ClosestTaskAt)
+		path += customFieldsFilter(filter.CustomFields)
+		path += listFilter("id", filter.IDs)
+		path += listFilter("responsible_user_id", filter.ResponsibleUserID)
 	}
 
 	var resp ContactsResponse
-	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+	header, err := s.client.getJSONHeader(ctx, path, &resp)
+	if err != nil {
 		return nil, err
 	}
+	resp.Header = header
 
-	return resp.Embedded.Contacts, nil
+	return &resp, nil
 }
 
 // GetByID retrieves a contact by ID
 func (s *ContactsService) GetByID(ctx context.Context, id int) (*Contact, error) {
+	return s.GetByIDWith(ctx, id, "")
+}
+
+// GetByIDWith retrieves a contact by ID, embedding the related entities
+// listed in with (comma-separated: leads, customers, catalog_elements)
+// into Contact.Embedded
+func (s *ContactsService) GetByIDWith(ctx context.Context, id int, with string) (*Contact, error) {
 	path := fmt.Sprintf("/contacts/%d", id)
+	if with != "" {
+		path += "?with=" + with
+	}
 
 	var contact Contact
 	if err := s.client.GetJSON(ctx, path, &contact); err != nil {
@@ -96,6 +194,44 @@ func (s *ContactsService) GetByID(ctx context.Context, id int) (*Contact, error)
 	return &contact, nil
 }
 
+// GetByIDs retrieves contacts by their IDs. IDs are requested in chunks of
+// maxIDsPerRequest, one request per chunk.
+func (s *ContactsService) GetByIDs(ctx context.Context, ids []int) ([]Contact, error) {
+	return listByIDChunks(ids, func(chunk []int) ([]Contact, error) {
+		path := fmt.Sprintf("/contacts?limit=%d&", len(chunk))
+		path += listFilter("id", chunk)
+
+		var resp ContactsResponse
+		if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+			return nil, err
+		}
+
+		return resp.Embedded.Contacts, nil
+	})
+}
+
+// Leads retrieves the leads linked to a contact. The contact embeds only
+// lead IDs, so the leads themselves are fetched with one more request.
+func (s *ContactsService) Leads(ctx context.Context, contactID int) ([]Lead, error) {
+	path := fmt.Sprintf("/contacts/%d?with=leads", contactID)
+
+	var contact Contact
+	if err := s.client.GetJSON(ctx, path, &contact); err != nil {
+		return nil, err
+	}
+
+	if contact.Embedded == nil || len(contact.Embedded.Leads) == 0 {
+		return []Lead{}, nil
+	}
+
+	ids := make([]int, len(contact.Embedded.Leads))
+	for i, lead := range contact.Embedded.Leads {
+		ids[i] = lead.ID
+	}
+
+	return s.client.Leads.GetByIDs(ctx, ids, "")
+}
+
 // Create creates a new contact
 func (s *ContactsService) Create(ctx context.Context, contact *Contact) (*Contact, error) {
 	type request struct {
@@ -194,3 +330,35 @@ func (s *ContactsService) UpdateBatch(ctx context.Context, contacts []*Contact)
 
 	return resp.Embedded.Contacts, nil
 }
+
+// Delete deletes a contact by ID
+func (s *ContactsService) Delete(ctx context.Context, id int) error {
+	if id == 0 {
+		return fmt.Errorf("contact ID is required for delete")
+	}
+
+	path := fmt.Sprintf("/contacts/%d", id)
+	return s.client.DeleteJSON(ctx, path)
+}
+
+// DeleteBatch deletes multiple contacts in one request. If only some of
+// the contacts were deleted, a *PartialDeleteError lists the failed IDs.
+func (s *ContactsService) DeleteBatch(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("no contact IDs to delete")
+	}
+	for i, id := range ids {
+		if id == 0 {
+			return fmt.Errorf("contact ID is required for delete at index %d", i)
+		}
+	}
+
+	_, err := s.client.deleteBatch(ctx, EntityTypeContact, ids)
+	return err
+}
+
+// GetLinks retrieves the entities a contact is linked to. The filter is
+// optional and may narrow the result to one entity type.
+func (s *ContactsService) GetLinks(ctx context.Context, contactID int, filter *LinksFilter) ([]EntityLink, error) {
+	return s.client.getLinks(ctx, EntityTypeContact, contactID, filter)
+}
@@ -0,0 +1,222 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestContactsService_Leads(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v4/contacts/1":
+			if r.URL.Query().Get("with") != "leads" {
+				t.Errorf("Expected with=leads, got '%s'", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"id": 1, "name": "Ivan", "_embedded": {"leads": [{"id": 10}, {"id": 11}]}}`))
+		case "/api/v4/contacts/2":
+			w.Write([]byte(`{"id": 2, "name": "Petr", "_embedded": {"leads": []}}`))
+		case "/api/v4/leads":
+			if got := r.URL.Query()["filter[id][]"]; len(got) != 2 {
+				t.Errorf("Expected 2 lead IDs, got %v", got)
+			}
+			w.Write([]byte(`{"_embedded": {"leads": [{"id": 10, "name": "Deal A"}, {"id": 11, "name": "Deal B"}]}}`))
+		default:
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	leads, err := client.Contacts.Leads(ctx, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(leads) != 2 || leads[0].Name != "Deal A" {
+		t.Errorf("Unexpected leads: %+v", leads)
+	}
+
+	leads, err = client.Contacts.Leads(ctx, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if leads == nil || len(leads) != 0 {
+		t.Errorf("Expected empty leads, got %+v", leads)
+	}
+}
+
+func TestContactsService_GetByIDsChunked(t *testing.T) {
+	var limits []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		ids := query["filter[id][]"]
+		if len(ids) > 250 {
+			t.Errorf("Expected at most 250 IDs per request, got %d", len(ids))
+		}
+		limits = append(limits, query.Get("limit"))
+
+		contacts := make([]Contact, len(ids))
+		for i, id := range ids {
+			contacts[i].ID, _ = strconv.Atoi(id)
+		}
+
+		var resp ContactsResponse
+		resp.Embedded.Contacts = contacts
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	ids := make([]int, 260)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	contacts, err := client.Contacts.GetByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(limits) != 2 || limits[0] != "250" || limits[1] != "10" {
+		t.Errorf("Expected limits [250 10], got %v", limits)
+	}
+	if len(contacts) != len(ids) || contacts[259].ID != 260 {
+		t.Errorf("Expected all %d contacts, got %d", len(ids), len(contacts))
+	}
+}
+
+func TestContactsService_Delete(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/contacts/5" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Contacts.Delete(context.Background(), 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.Contacts.Delete(context.Background(), 0); err == nil {
+		t.Error("Expected error for missing contact ID")
+	}
+}
+
+func TestContactsService_DeleteBatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/contacts" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body []struct {
+			ID int `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if len(body) == 1 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"contacts": [{"id": 1}]}}`))
+	})
+
+	if err := client.Contacts.DeleteBatch(context.Background(), []int{1}); err != nil {
+		t.Fatalf("Expected 204 to be treated as success, got %v", err)
+	}
+
+	err := client.Contacts.DeleteBatch(context.Background(), []int{1, 2})
+	var partial *PartialDeleteError
+	if !errors.As(err, &partial) {
+		t.Fatalf("Expected PartialDeleteError, got %v", err)
+	}
+	if len(partial.FailedIDs) != 1 || partial.FailedIDs[0] != 2 {
+		t.Errorf("Expected failed IDs [2], got %v", partial.FailedIDs)
+	}
+
+	if err := client.Contacts.DeleteBatch(context.Background(), []int{1, 0}); err == nil {
+		t.Error("Expected error for zero ID")
+	}
+}
+
+func TestContactCustomFieldAccessors(t *testing.T) {
+	var contact Contact
+	err := json.Unmarshal([]byte(`{"id": 1, "custom_fields_values": [
+		{"field_id": 10, "field_code": "PHONE", "values": [{"value": "+79001", "enum_code": "WORK"}, {"value": "+79002", "enum_code": "MOB"}]},
+		{"field_id": 11, "values": [{"value": 42.5}]},
+		{"field_id": 12, "values": [{"value": {"name": "ООО Ромашка", "vat_id": "7700"}}]}
+	]}`), &contact)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value, ok := contact.CustomFieldValue(10); !ok || value != "+79001" {
+		t.Errorf("Expected first phone '+79001', got '%s'", value)
+	}
+
+	if phones := contact.CustomFieldValues(10); len(phones) != 2 || phones[1] != "+79002" {
+		t.Errorf("Unexpected phones: %v", phones)
+	}
+
+	if value, _ := contact.CustomFieldValue(11); value != "42.5" {
+		t.Errorf("Expected numeric value '42.5', got '%s'", value)
+	}
+
+	if value, _ := contact.CustomFieldValue(12); value != `{"name":"ООО Ромашка","vat_id":"7700"}` {
+		t.Errorf("Unexpected enriched value '%s'", value)
+	}
+
+	if _, ok := contact.CustomFieldValue(99); ok {
+		t.Error("Expected no value for unknown field")
+	}
+
+	if field := contact.FieldByCode("PHONE"); field == nil || field.FieldID != 10 {
+		t.Errorf("Unexpected field by code: %+v", field)
+	}
+}
+
+func TestContactsService_ListWithResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "5")
+		w.Write([]byte(`{"_page": 1, "_embedded": {"contacts": [{"id": 1}]}, "_links": {"next": {"href": "page2"}}}`))
+	})
+
+	resp, err := client.Contacts.ListWithResponse(context.Background(), &ContactsFilter{Page: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(resp.Embedded.Contacts) != 1 || !resp.Links.HasNext() {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "5" {
+		t.Errorf("Expected X-RateLimit-Remaining '5', got '%s'", got)
+	}
+}
+
+func TestContactsService_GetByIDWith(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/contacts/5" {
+			t.Errorf("Expected path '/api/v4/contacts/5', got '%s'", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("with"); got != "leads,customers" {
+			t.Errorf("Expected with 'leads,customers', got '%s'", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 5, "_embedded": {"leads": [{"id": 10}, {"id": 11}]}}`))
+	})
+
+	contact, err := client.Contacts.GetByIDWith(context.Background(), 5, "leads,customers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if contact.Embedded == nil || len(contact.Embedded.Leads) != 2 || contact.Embedded.Leads[1].ID != 11 {
+		t.Errorf("Expected embedded leads [10 11], got %+v", contact.Embedded)
+	}
+}
@@ -0,0 +1,103 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey int
+
+const (
+	responseMetaKey contextKey = iota
+	requestSourceKey
+	requestHeadersKey
+)
+
+// ResponseMeta holds metadata of the HTTP response of an API call
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// WithResponseMeta returns a context that makes the client record the
+// status and headers of the response into meta. The context should be
+// scoped to a single call; the last response wins when it is reused.
+//
+//	var meta amocrm.ResponseMeta
+//	_, err := client.Leads.Update(amocrm.WithResponseMeta(ctx, &meta), lead)
+//	fmt.Println(meta.StatusCode)
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey, meta)
+}
+
+// recordResponseMeta stores resp metadata if the context asks for it
+func recordResponseMeta(ctx context.Context, resp *http.Response) {
+	meta, ok := ctx.Value(responseMetaKey).(*ResponseMeta)
+	if !ok || meta == nil {
+		return
+	}
+
+	meta.StatusCode = resp.StatusCode
+	meta.Header = resp.Header.Clone()
+}
+
+// RequestSource attributes created entities to an integration
+type RequestSource struct {
+	Name string // source_name
+	UID  string // source_uid
+}
+
+// WithRequestSource returns a context whose create calls attribute the new
+// entities to source. It is honored by the endpoints whose entities carry
+// source fields, i.e. Unsorted.Create (/leads/unsorted/forms and
+// /leads/unsorted/sip), and only fills items that have no source set.
+// Other create endpoints have no source fields and ignore it.
+func WithRequestSource(ctx context.Context, source RequestSource) context.Context {
+	return context.WithValue(ctx, requestSourceKey, source)
+}
+
+// requestSource returns the source attached to the context, if any
+func requestSource(ctx context.Context) (RequestSource, bool) {
+	source, ok := ctx.Value(requestSourceKey).(RequestSource)
+	return source, ok
+}
+
+// WithRequestHeaders returns a context whose requests carry the given
+// headers, e.g. X-Request-Id for tracing. They are set after the client
+// defaults, so they override Content-Type and User-Agent; Authorization is
+// always set by the client and cannot be overridden.
+func WithRequestHeaders(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, requestHeadersKey, header)
+}
+
+// applyRequestHeaders copies the headers attached to the context onto req
+func applyRequestHeaders(ctx context.Context, req *http.Request) {
+	header, ok := ctx.Value(requestHeadersKey).(http.Header)
+	if !ok {
+		return
+	}
+
+	for key, values := range header {
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			continue
+		}
+		req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+}
+
+// perCallContext reports whether ctx carries values that apply to a single
+// call only, so the call must not be shared with other callers
+func perCallContext(ctx context.Context) bool {
+	return ctx.Value(requestHeadersKey) != nil || ctx.Value(responseMetaKey) != nil
+}
+
+// detachedContext returns a context with the values of ctx but without its
+// cancellation, for work shared by several callers. It is bounded by the
+// HTTP client timeout, if one is set.
+func (c *Client) detachedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if c.httpClient.Timeout > 0 {
+		return context.WithTimeout(detached, c.httpClient.Timeout)
+	}
+	return context.WithCancel(detached)
+}
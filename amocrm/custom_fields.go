@@ -0,0 +1,285 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CustomField represents a custom field definition
+type CustomField struct {
+	ID         int               `json:"id,omitempty"`
+	Name       string            `json:"name"`
+	Type       string            `json:"type"` // text, numeric, checkbox, select, multiselect, date, url, textarea, radiobutton, ...
+	Code       string            `json:"code,omitempty"`
+	Sort       int               `json:"sort,omitempty"`
+	EntityType string            `json:"entity_type,omitempty"`
+	GroupID    string            `json:"group_id,omitempty"`
+	IsAPIOnly  bool              `json:"is_api_only,omitempty"`
+	Enums      []CustomFieldEnum `json:"enums,omitempty"`
+	AccountID  int               `json:"account_id,omitempty"`
+	Links      *Links            `json:"_links,omitempty"`
+}
+
+// CustomFieldEnum represents an option of a select-like custom field
+type CustomFieldEnum struct {
+	ID    int    `json:"id,omitempty"`
+	Value string `json:"value"`
+	Sort  int    `json:"sort,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
+// CustomFieldsService handles communication with custom field methods
+type CustomFieldsService struct {
+	client *Client
+
+	schemaMu sync.Mutex
+	schemas  map[EntityType]customFieldsSchema
+}
+
+// CustomFieldsResponse represents the API response for custom fields list
+type CustomFieldsResponse struct {
+	Embedded struct {
+		CustomFields []CustomField `json:"custom_fields"`
+	} `json:"_embedded"`
+	Links Links `json:"_links"`
+	Page  int   `json:"_page,omitempty"`
+}
+
+// CustomFieldsFilter represents filter options for listing custom fields
+type CustomFieldsFilter struct {
+	Limit int
+	Page  int
+	Type  []string
+}
+
+// customFieldsSchemaTTL is how long Schema results are cached
+const customFieldsSchemaTTL = 10 * time.Minute
+
+type customFieldsSchema struct {
+	fields    []CustomField
+	fetchedAt time.Time
+}
+
+// List retrieves custom fields of an entity type
+func (s *CustomFieldsService) List(ctx context.Context, entityType EntityType, filter *CustomFieldsFilter) ([]CustomField, error) {
+	resp, err := s.list(ctx, entityType, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.CustomFields, nil
+}
+
+func (s *CustomFieldsService) list(ctx context.Context, entityType EntityType, filter *CustomFieldsFilter) (*CustomFieldsResponse, error) {
+	path := fmt.Sprintf("/%s/custom_fields", entityType)
+
+	if filter != nil {
+		path += "?"
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		for _, fieldType := range filter.Type {
+			path += fmt.Sprintf("filter[type][]=%s&", fieldType)
+		}
+	}
+
+	var resp CustomFieldsResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Create creates a custom field of an entity type and drops the cached
+// schema of that type
+func (s *CustomFieldsService) Create(ctx context.Context, entityType EntityType, field *CustomField) (*CustomField, error) {
+	if field.Name == "" || field.Type == "" {
+		return nil, fmt.Errorf("custom field name and type are required")
+	}
+
+	type request struct {
+		CustomFields []CustomField `json:"custom_fields"`
+	}
+
+	req := request{
+		CustomFields: []CustomField{*field},
+	}
+
+	path := fmt.Sprintf("/%s/custom_fields", entityType)
+
+	var resp CustomFieldsResponse
+	if err := s.client.PostJSON(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	s.InvalidateSchema(entityType)
+
+	if len(resp.Embedded.CustomFields) == 0 {
+		return nil, fmt.Errorf("no custom field returned from API")
+	}
+
+	return &resp.Embedded.CustomFields[0], nil
+}
+
+// Update updates a custom field of an entity type and drops the cached
+// schema of that type. Enums replace the existing options: options missing
+// from the list are removed, so pass the IDs of the options to keep.
+func (s *CustomFieldsService) Update(ctx context.Context, entityType EntityType, field *CustomField) (*CustomField, error) {
+	if field.ID == 0 {
+		return nil, fmt.Errorf("custom field ID is required for update")
+	}
+
+	type request struct {
+		CustomFields []CustomField `json:"custom_fields"`
+	}
+
+	req := request{
+		CustomFields: []CustomField{*field},
+	}
+
+	path := fmt.Sprintf("/%s/custom_fields", entityType)
+
+	var resp CustomFieldsResponse
+	if err := s.client.PatchJSON(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	s.InvalidateSchema(entityType)
+
+	if len(resp.Embedded.CustomFields) == 0 {
+		return nil, fmt.Errorf("no custom field returned from API")
+	}
+
+	return &resp.Embedded.CustomFields[0], nil
+}
+
+// Schema returns all custom fields of an entity type. The full list is
+// fetched page by page and cached for 10 minutes; use InvalidateSchema to
+// force a refresh. The returned slice is a copy, but the enums of the
+// fields are shared with the cache and must not be modified.
+func (s *CustomFieldsService) Schema(ctx context.Context, entityType EntityType) ([]CustomField, error) {
+	s.schemaMu.Lock()
+	cached, ok := s.schemas[entityType]
+	s.schemaMu.Unlock()
+
+	if ok && timeNow().Sub(cached.fetchedAt) < customFieldsSchemaTTL {
+		return append([]CustomField(nil), cached.fields...), nil
+	}
+
+	var fields []CustomField
+	filter := &CustomFieldsFilter{Limit: 50, Page: 1}
+	for {
+		resp, err := s.list(ctx, entityType, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, resp.Embedded.CustomFields...)
+		if !resp.Links.HasNext() || len(resp.Embedded.CustomFields) == 0 {
+			break
+		}
+		filter.Page++
+	}
+
+	s.schemaMu.Lock()
+	if s.schemas == nil {
+		s.schemas = make(map[EntityType]customFieldsSchema)
+	}
+	s.schemas[entityType] = customFieldsSchema{fields: fields, fetchedAt: timeNow()}
+	s.schemaMu.Unlock()
+
+	return append([]CustomField(nil), fields...), nil
+}
+
+// InvalidateSchema drops the cached schema of an entity type
+func (s *CustomFieldsService) InvalidateSchema(entityType EntityType) {
+	s.schemaMu.Lock()
+	defer s.schemaMu.Unlock()
+	delete(s.schemas, entityType)
+}
+
+// EnumsByCode returns the enum options of every select-like field of an
+// entity type, keyed by field code. Fields without a code are skipped; use
+// EnumsByFieldID for those.
+func (s *CustomFieldsService) EnumsByCode(ctx context.Context, entityType EntityType) (map[string][]CustomFieldEnum, error) {
+	fields, err := s.Schema(ctx, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	enums := make(map[string][]CustomFieldEnum)
+	for _, field := range fields {
+		if field.Code != "" && len(field.Enums) > 0 {
+			enums[field.Code] = field.Enums
+		}
+	}
+
+	return enums, nil
+}
+
+// EnumsByFieldID returns the enum options of every select-like field of an
+// entity type, keyed by field ID
+func (s *CustomFieldsService) EnumsByFieldID(ctx context.Context, entityType EntityType) (map[int][]CustomFieldEnum, error) {
+	fields, err := s.Schema(ctx, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	enums := make(map[int][]CustomFieldEnum)
+	for _, field := range fields {
+		if len(field.Enums) > 0 {
+			enums[field.ID] = field.Enums
+		}
+	}
+
+	return enums, nil
+}
+
+// EnumLabel returns the label of an enum option of the field
+func (f *CustomField) EnumLabel(enumID int) (string, bool) {
+	for _, enum := range f.Enums {
+		if enum.ID == enumID {
+			return enum.Value, true
+		}
+	}
+	return "", false
+}
+
+// EnumLabels resolves the enum IDs of a select-like field value to their
+// labels using the field definition. A multiselect value yields one label
+// per selected option; values without a known enum ID keep the label sent
+// by the API, if any.
+func (v *CustomFieldValue) EnumLabels(field *CustomField) []string {
+	labels := make([]string, 0, len(v.Values))
+	for _, value := range v.Values {
+		if label, ok := field.EnumLabel(value.EnumID); ok {
+			labels = append(labels, label)
+		} else if value.Enum != "" {
+			labels = append(labels, value.Enum)
+		}
+	}
+	return labels
+}
+
+// EnumLabels resolves the enum IDs of a custom field value of an entity
+// type to their labels, using the cached field schema
+func (s *CustomFieldsService) EnumLabels(ctx context.Context, entityType EntityType, value *CustomFieldValue) ([]string, error) {
+	fields, err := s.Schema(ctx, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range fields {
+		if fields[i].ID == value.FieldID {
+			return value.EnumLabels(&fields[i]), nil
+		}
+	}
+
+	return nil, fmt.Errorf("custom field %d not found for %s", value.FieldID, entityType)
+}
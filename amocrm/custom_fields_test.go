@@ -0,0 +1,143 @@
+package amocrm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCustomFieldsService_EnumsByCode(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/v4/contacts/custom_fields" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"_links": {"next": {"href": "next"}}, "_embedded": {"custom_fields": [
+				{"id": 1, "name": "Телефон", "type": "multitext", "code": "PHONE", "enums": [{"id": 11, "value": "WORK"}, {"id": 12, "value": "MOB"}]},
+				{"id": 2, "name": "Должность", "type": "text", "code": "POSITION"}
+			]}}`))
+			return
+		}
+		w.Write([]byte(`{"_embedded": {"custom_fields": [
+			{"id": 3, "name": "Источник", "type": "select", "enums": [{"id": 31, "value": "Сайт"}]}
+		]}}`))
+	})
+
+	ctx := context.Background()
+	byCode, err := client.CustomFields.EnumsByCode(ctx, EntityTypeContact)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(byCode) != 1 || len(byCode["PHONE"]) != 2 {
+		t.Errorf("Unexpected enums by code: %v", byCode)
+	}
+
+	byID, err := client.CustomFields.EnumsByFieldID(ctx, EntityTypeContact)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(byID) != 2 || byID[3][0].Value != "Сайт" {
+		t.Errorf("Unexpected enums by field ID: %v", byID)
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected the schema to be fetched once over 2 pages, got %d requests", requests)
+	}
+}
+
+func TestCustomFieldsService_SchemaCopy(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"custom_fields": [
+			{"id": 1, "name": "Телефон", "code": "PHONE"},
+			{"id": 2, "name": "Email", "code": "EMAIL"}
+		]}}`))
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		fields, err := client.CustomFields.Schema(ctx, EntityTypeContact)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fields[0].Code != "PHONE" {
+			t.Fatalf("Expected the cached schema to be unchanged, got %+v", fields)
+		}
+
+		// Modifying the result must not leak into the cache
+		fields[0], fields[1] = fields[1], fields[0]
+		fields[1].Code = ""
+	}
+
+	fields, err := client.CustomFields.Schema(ctx, EntityTypeContact)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fields[0].Code != "PHONE" || fields[1].Code != "EMAIL" {
+		t.Errorf("Expected the cached schema to be unchanged, got %+v", fields)
+	}
+}
+
+func TestCustomFieldsService_EnumLabels(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"custom_fields": [
+			{"id": 5, "name": "Интересы", "type": "multiselect", "enums": [{"id": 51, "value": "Авто"}, {"id": 52, "value": "Спорт"}, {"id": 53, "value": "Музыка"}]}
+		]}}`))
+	})
+
+	value := &CustomFieldValue{FieldID: 5, Values: []FieldValue{{EnumID: 53}, {EnumID: 51}, {EnumID: 99, Enum: "Удалённый"}}}
+	labels, err := client.CustomFields.EnumLabels(context.Background(), EntityTypeLead, value)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(labels) != 3 || labels[0] != "Музыка" || labels[1] != "Авто" || labels[2] != "Удалённый" {
+		t.Errorf("Unexpected labels: %v", labels)
+	}
+
+	if _, err := client.CustomFields.EnumLabels(context.Background(), EntityTypeLead, &CustomFieldValue{FieldID: 6}); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
+
+func TestCustomFieldsService_Create(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/leads/custom_fields" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"custom_fields":[{"name":"Источник","type":"select","enums":[{"value":"Сайт"}]}]}`
+		if string(body) != expected {
+			t.Errorf("Expected body %s, got %s", expected, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"custom_fields": [{"id": 7, "name": "Источник", "type": "select", "enums": [{"id": 71, "value": "Сайт"}]}]}}`))
+	})
+
+	field, err := client.CustomFields.Create(context.Background(), EntityTypeLead, &CustomField{
+		Name:  "Источник",
+		Type:  "select",
+		Enums: []CustomFieldEnum{{Value: "Сайт"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if field.ID != 7 || field.Enums[0].ID != 71 {
+		t.Errorf("Unexpected field: %+v", field)
+	}
+
+	if _, err := client.CustomFields.Update(context.Background(), EntityTypeLead, &CustomField{Name: "Без ID"}); err == nil {
+		t.Error("Expected error for missing ID")
+	}
+}
@@ -0,0 +1,160 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+)
+
+// Customer represents an AmoCRM customer (buyer)
+type Customer struct {
+	ID                 int                `json:"id,omitempty"`
+	Name               string             `json:"name"`
+	NextPrice          int                `json:"next_price,omitempty"`
+	NextDate           int64              `json:"next_date,omitempty"`
+	ResponsibleUserID  int                `json:"responsible_user_id,omitempty"`
+	StatusID           int                `json:"status_id,omitempty"`
+	Periodicity        int                `json:"periodicity,omitempty"`
+	CreatedBy          int                `json:"created_by,omitempty"`
+	UpdatedBy          int                `json:"updated_by,omitempty"`
+	CreatedAt          int64              `json:"created_at,omitempty"`
+	UpdatedAt          int64              `json:"updated_at,omitempty"`
+	ClosestTaskAt      int64              `json:"closest_task_at,omitempty"`
+	IsDeleted          bool               `json:"is_deleted,omitempty"`
+	CustomFieldsValues []CustomFieldValue `json:"custom_fields_values,omitempty"`
+	LTV                int                `json:"ltv,omitempty"`
+	PurchasesCount     int                `json:"purchases_count,omitempty"`
+	AverageCheck       int                `json:"average_check,omitempty"`
+	AccountID          int                `json:"account_id,omitempty"`
+	Links              *Links             `json:"_links,omitempty"`
+	Embedded           *Embedded          `json:"_embedded,omitempty"`
+}
+
+// CustomersService handles communication with customer-related methods.
+// Customers are available only when the account customers mode is enabled.
+type CustomersService struct {
+	client *Client
+}
+
+// CustomersResponse represents the API response for customers list
+type CustomersResponse struct {
+	Embedded struct {
+		Customers []Customer `json:"customers"`
+	} `json:"_embedded"`
+	Links Links `json:"_links"`
+	Page  Page  `json:"_page,omitempty"`
+}
+
+// CustomersFilter represents filter options for listing customers
+type CustomersFilter struct {
+	Query             string
+	Limit             int
+	Page              int
+	With              string // comma-separated list: catalog_elements, contacts, companies, segments
+	ResponsibleUserID []int
+}
+
+// List retrieves a list of customers
+func (s *CustomersService) List(ctx context.Context, filter *CustomersFilter) ([]Customer, error) {
+	path := "/customers"
+
+	if filter != nil {
+		path += "?"
+		if filter.Query != "" {
+			path += searchQuery(filter.Query)
+		}
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		if filter.With != "" {
+			path += fmt.Sprintf("with=%s&", filter.With)
+		}
+		for _, userID := range filter.ResponsibleUserID {
+			path += fmt.Sprintf("filter[responsible_user_id][]=%d&", userID)
+		}
+	}
+
+	var resp CustomersResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Customers, nil
+}
+
+// GetByID retrieves a customer by ID
+func (s *CustomersService) GetByID(ctx context.Context, id int) (*Customer, error) {
+	path := fmt.Sprintf("/customers/%d", id)
+
+	var customer Customer
+	if err := s.client.GetJSON(ctx, path, &customer); err != nil {
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+// Create creates a new customer
+func (s *CustomersService) Create(ctx context.Context, customer *Customer) (*Customer, error) {
+	customers, err := s.CreateBatch(ctx, []*Customer{customer})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(customers) == 0 {
+		return nil, fmt.Errorf("no customer returned from API")
+	}
+
+	return &customers[0], nil
+}
+
+// CreateBatch creates multiple customers in one request
+func (s *CustomersService) CreateBatch(ctx context.Context, customers []*Customer) ([]Customer, error) {
+	type request struct {
+		Customers []Customer `json:"customers"`
+	}
+
+	customersValues := make([]Customer, len(customers))
+	for i, c := range customers {
+		customersValues[i] = *c
+	}
+
+	req := request{
+		Customers: customersValues,
+	}
+
+	var resp CustomersResponse
+	if err := s.client.PostJSON(ctx, "/customers", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Customers, nil
+}
+
+// Update updates an existing customer
+func (s *CustomersService) Update(ctx context.Context, customer *Customer) (*Customer, error) {
+	if customer.ID == 0 {
+		return nil, fmt.Errorf("customer ID is required for update")
+	}
+
+	type request struct {
+		Customers []Customer `json:"customers"`
+	}
+
+	req := request{
+		Customers: []Customer{*customer},
+	}
+
+	var resp CustomersResponse
+	if err := s.client.PatchJSON(ctx, "/customers", req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Embedded.Customers) == 0 {
+		return nil, fmt.Errorf("no customer returned from API")
+	}
+
+	return &resp.Embedded.Customers[0], nil
+}
@@ -0,0 +1,63 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCustomersService_List(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/customers" {
+			t.Errorf("Expected path '/api/v4/customers', got '%s'", r.URL.Path)
+		}
+
+		expected := "limit=50&filter[responsible_user_id][]=1&filter[responsible_user_id][]=2&"
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"customers": [{"id": 1, "name": "Regular", "next_price": 1500, "next_date": 1700000000}]}}`))
+	})
+
+	customers, err := client.Customers.List(context.Background(), &CustomersFilter{
+		Limit:             50,
+		ResponsibleUserID: []int{1, 2},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(customers) != 1 || customers[0].NextPrice != 1500 || customers[0].NextDate != 1700000000 {
+		t.Errorf("Unexpected customers: %+v", customers)
+	}
+}
+
+func TestCustomersService_Create(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Customers []Customer `json:"customers"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != http.MethodPost || len(body.Customers) != 1 || body.Customers[0].Name != "New" {
+			t.Errorf("Unexpected request %s with %+v", r.Method, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"customers": [{"id": 7, "name": "New"}]}}`))
+	})
+
+	customer, err := client.Customers.Create(context.Background(), &Customer{Name: "New"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if customer.ID != 7 {
+		t.Errorf("Expected customer ID 7, got %d", customer.ID)
+	}
+
+	if _, err := client.Customers.Update(context.Background(), &Customer{Name: "No ID"}); err == nil {
+		t.Error("Expected error for update without ID")
+	}
+}
@@ -0,0 +1,93 @@
+package amocrm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBodyLogLimit is the number of body bytes logged in debug mode
+const DefaultBodyLogLimit = 4096
+
+// redacted replaces secrets in debug logs
+const redacted = "[REDACTED]"
+
+// sensitiveFormFields are form fields whose values are never logged
+var sensitiveFormFields = []string{"client_secret", "code", "refresh_token", "access_token"}
+
+// logRequest logs an outgoing request with its headers and body. The
+// Authorization header and OAuth secrets of form bodies are redacted.
+func (c *Client) logRequest(req *http.Request, contentType string, body []byte) {
+	header := req.Header.Clone()
+	if header.Get("Authorization") != "" {
+		header.Set("Authorization", redacted)
+	}
+
+	if contentType == contentTypeForm {
+		body = redactForm(body)
+	}
+
+	c.logger.Debug("API Request",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"headers", header,
+		"body", c.truncateBody(body),
+	)
+}
+
+// logResponse logs a response with its body. The body is read in full and
+// replaced with an in-memory copy, so callers can still consume it.
+func (c *Client) logResponse(resp *http.Response, rawURL string) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	var replay io.Reader = bytes.NewReader(body)
+	if err != nil {
+		// Hand the read error on to the caller after the buffered part
+		replay = io.MultiReader(replay, errorReader{err})
+	}
+	resp.Body = io.NopCloser(replay)
+
+	c.logger.Debug("API Response",
+		"status", resp.StatusCode,
+		"url", rawURL,
+		"body", c.truncateBody(body),
+	)
+}
+
+// truncateBody renders a body for logging, cut to the configured limit
+func (c *Client) truncateBody(body []byte) string {
+	if c.bodyLogLimit > 0 && len(body) > c.bodyLogLimit {
+		return fmt.Sprintf("%s... (%d bytes truncated)", body[:c.bodyLogLimit], len(body)-c.bodyLogLimit)
+	}
+	return string(body)
+}
+
+// redactForm hides the values of sensitive fields of a form body
+func redactForm(body []byte) []byte {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return []byte(redacted)
+	}
+
+	for _, field := range sensitiveFormFields {
+		if values.Has(field) {
+			values.Set(field, redacted)
+		}
+	}
+
+	// Keep the redaction marker readable
+	return []byte(strings.ReplaceAll(values.Encode(), url.QueryEscape(redacted), redacted))
+}
+
+// errorReader replays a read error after the buffered part of a body
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,78 @@
+package amocrm
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newDebugTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) (*Client, *bytes.Buffer) {
+	t.Helper()
+
+	var logs bytes.Buffer
+	client := newTestClient(t, handler)
+	client.logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client.debug = true
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, &logs
+}
+
+func TestDebugLogsBodies(t *testing.T) {
+	client, logs := newDebugTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"leads": [{"id": 7, "name": "` + strings.Repeat("x", 100) + `"}]}}`))
+	}, WithBodyLogging(40))
+
+	leads, err := client.Leads.CreateBatch(context.Background(), []*Lead{{Name: "New lead"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(leads) != 1 || leads[0].ID != 7 {
+		t.Errorf("Expected the logged response to still decode, got %v", leads)
+	}
+
+	out := logs.String()
+	if !strings.Contains(out, "New lead") {
+		t.Errorf("Expected the request body to be logged, got %s", out)
+	}
+	if !strings.Contains(out, "bytes truncated") {
+		t.Errorf("Expected the response body to be truncated, got %s", out)
+	}
+	if strings.Contains(out, "test-token") || !strings.Contains(out, redacted) {
+		t.Errorf("Expected the Authorization header to be redacted, got %s", out)
+	}
+}
+
+func TestDebugLogsFullBodies(t *testing.T) {
+	long := strings.Repeat("y", DefaultBodyLogLimit+10)
+	client, logs := newDebugTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "` + long + `"}`))
+	}, WithBodyLogging(0))
+
+	if _, err := client.Leads.GetByID(context.Background(), 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(logs.String(), long) || strings.Contains(logs.String(), "truncated") {
+		t.Error("Expected the full response body to be logged")
+	}
+}
+
+func TestRedactForm(t *testing.T) {
+	body := []byte("client_id=id&client_secret=s3cret&grant_type=refresh_token&refresh_token=r3fresh")
+
+	got := string(redactForm(body))
+	if strings.Contains(got, "s3cret") || strings.Contains(got, "r3fresh") {
+		t.Errorf("Expected secrets to be redacted, got %s", got)
+	}
+	if !strings.Contains(got, "client_id=id") || !strings.Contains(got, "client_secret="+redacted) {
+		t.Errorf("Expected other fields to be kept, got %s", got)
+	}
+}
@@ -1,21 +1,185 @@
 package amocrm
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
-// APIError represents an API error response
+// ErrFeatureUnavailable is matched by API errors returned when the account
+// plan does not include the requested feature
+var ErrFeatureUnavailable = errors.New("feature is not available for this account")
+
+// Sentinel errors matched by API errors of the corresponding status, e.g.
+// errors.Is(err, ErrNotFound)
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// statusErrors maps sentinel errors to the status they match
+var statusErrors = map[error]int{
+	ErrNotFound:     http.StatusNotFound,
+	ErrUnauthorized: http.StatusUnauthorized,
+	ErrRateLimited:  http.StatusTooManyRequests,
+}
+
+// featureUnavailablePatterns are body fragments that mark a 403 response
+// as feature-gated rather than a permission problem
+var featureUnavailablePatterns = []string{
+	"payment required",
+	"not available",
+	"not paid",
+	"tariff",
+}
+
+// APIError represents an API error response. Title, Detail and
+// ValidationErrors are filled from the AmoCRM error document when the body
+// is one; Message always holds the raw body.
 type APIError struct {
-	StatusCode int
-	Message    string
+	StatusCode       int
+	Message          string
+	Title            string
+	Detail           string
+	ValidationErrors []ValidationError
+
+	retryAfter string // Retry-After header of the response
 }
 
 func (e *APIError) Error() string {
+	switch {
+	case e.Title != "" && e.Detail != "":
+		return fmt.Sprintf("API error (status %d): %s: %s", e.StatusCode, e.Title, e.Detail)
+	case e.Title != "":
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Title)
+	}
 	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
 }
 
-// ValidationError represents a validation error
+// newAPIError builds an APIError from an error response, decoding the
+// AmoCRM error document when the body contains one
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    string(body),
+		retryAfter: resp.Header.Get("Retry-After"),
+	}
+
+	var doc struct {
+		Title            string `json:"title"`
+		Detail           string `json:"detail"`
+		ValidationErrors []struct {
+			RequestID string `json:"request_id"`
+			Errors    []struct {
+				Code   string `json:"code"`
+				Path   string `json:"path"`
+				Detail string `json:"detail"`
+			} `json:"errors"`
+		} `json:"validation-errors"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return apiErr
+	}
+
+	apiErr.Title = doc.Title
+	apiErr.Detail = doc.Detail
+	for _, request := range doc.ValidationErrors {
+		for _, e := range request.Errors {
+			apiErr.ValidationErrors = append(apiErr.ValidationErrors, ValidationError{
+				RequestID: request.RequestID,
+				Code:      e.Code,
+				Field:     e.Path,
+				Message:   e.Detail,
+			})
+		}
+	}
+
+	return apiErr
+}
+
+// Is reports whether the error matches target, allowing
+// errors.Is(err, ErrFeatureUnavailable) and the status sentinels such as
+// errors.Is(err, ErrNotFound)
+func (e *APIError) Is(target error) bool {
+	if target == ErrFeatureUnavailable {
+		return e.featureUnavailable()
+	}
+
+	status, ok := statusErrors[target]
+	return ok && e.StatusCode == status
+}
+
+func (e *APIError) featureUnavailable() bool {
+	switch e.StatusCode {
+	case http.StatusPaymentRequired:
+		return true
+	case http.StatusForbidden:
+		message := strings.ToLower(e.Message)
+		for _, pattern := range featureUnavailablePatterns {
+			if strings.Contains(message, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsFeatureUnavailable reports whether err was caused by a feature that is
+// not included in the account plan
+func IsFeatureUnavailable(err error) bool {
+	return errors.Is(err, ErrFeatureUnavailable)
+}
+
+// IsNotFound reports whether err is an API error with status 404
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err is an API error with status 401
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRateLimited reports whether err is an API error with status 429
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsValidationError reports whether err is an API error rejecting the
+// request data: status 400 or 422, or a response listing validation errors
+func IsValidationError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusBadRequest ||
+		apiErr.StatusCode == http.StatusUnprocessableEntity ||
+		len(apiErr.ValidationErrors) > 0
+}
+
+// PartialDeleteError is returned by bulk deletes when only some of the
+// entities were deleted
+type PartialDeleteError struct {
+	EntityType EntityType
+	FailedIDs  []int
+}
+
+func (e *PartialDeleteError) Error() string {
+	return fmt.Sprintf("failed to delete %d %s: %v", len(e.FailedIDs), e.EntityType, e.FailedIDs)
+}
+
+// ValidationError represents a validation error. Field is the path of the
+// invalid value, e.g. "custom_fields_values.0.field_id"; RequestID is the
+// index of the entity in a batch request.
 type ValidationError struct {
-	Field   string
-	Message string
+	Field     string
+	Message   string
+	Code      string
+	RequestID string
 }
 
 func (e *ValidationError) Error() string {
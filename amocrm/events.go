@@ -0,0 +1,476 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// EventType represents the type of an event
+type EventType string
+
+// Event types of the account activity log
+const (
+	EventTypeLeadAdded             EventType = "lead_added"
+	EventTypeLeadDeleted           EventType = "lead_deleted"
+	EventTypeLeadRestored          EventType = "lead_restored"
+	EventTypeLeadStatusChanged     EventType = "lead_status_changed"
+	EventTypeLeadLinked            EventType = "lead_linked"
+	EventTypeLeadUnlinked          EventType = "lead_unlinked"
+	EventTypeContactAdded          EventType = "contact_added"
+	EventTypeContactDeleted        EventType = "contact_deleted"
+	EventTypeContactRestored       EventType = "contact_restored"
+	EventTypeContactLinked         EventType = "contact_linked"
+	EventTypeContactUnlinked       EventType = "contact_unlinked"
+	EventTypeCompanyAdded          EventType = "company_added"
+	EventTypeCompanyDeleted        EventType = "company_deleted"
+	EventTypeCompanyRestored       EventType = "company_restored"
+	EventTypeCompanyLinked         EventType = "company_linked"
+	EventTypeCompanyUnlinked       EventType = "company_unlinked"
+	EventTypeCustomerAdded         EventType = "customer_added"
+	EventTypeCustomerDeleted       EventType = "customer_deleted"
+	EventTypeCustomerStatusChanged EventType = "customer_status_changed"
+	EventTypeCustomerLinked        EventType = "customer_linked"
+	EventTypeCustomerUnlinked      EventType = "customer_unlinked"
+	EventTypeTaskAdded             EventType = "task_added"
+	EventTypeTaskDeleted           EventType = "task_deleted"
+	EventTypeTaskCompleted         EventType = "task_completed"
+	EventTypeTaskTypeChanged       EventType = "task_type_changed"
+	EventTypeTaskTextChanged       EventType = "task_text_changed"
+	EventTypeTaskDeadlineChanged   EventType = "task_deadline_changed"
+	EventTypeTaskResultAdded       EventType = "task_result_added"
+	EventTypeIncomingCall          EventType = "incoming_call"
+	EventTypeOutgoingCall          EventType = "outgoing_call"
+	EventTypeIncomingChatMessage   EventType = "incoming_chat_message"
+	EventTypeOutgoingChatMessage   EventType = "outgoing_chat_message"
+	EventTypeEntityDirectMessage   EventType = "entity_direct_message"
+	EventTypeIncomingSMS           EventType = "incoming_sms"
+	EventTypeOutgoingSMS           EventType = "outgoing_sms"
+	EventTypeEntityTagAdded        EventType = "entity_tag_added"
+	EventTypeEntityTagDeleted      EventType = "entity_tag_deleted"
+	EventTypeEntityLinked          EventType = "entity_linked"
+	EventTypeEntityUnlinked        EventType = "entity_unlinked"
+	EventTypeEntityMerged          EventType = "entity_merged"
+	EventTypeEntityResponsible     EventType = "entity_responsible_changed"
+	EventTypeSaleFieldChanged      EventType = "sale_field_changed"
+	EventTypeNameFieldChanged      EventType = "name_field_changed"
+	EventTypeLTVFieldChanged       EventType = "ltv_field_changed"
+	EventTypeCommonNoteAdded       EventType = "common_note_added"
+	EventTypeCommonNoteDeleted     EventType = "common_note_deleted"
+	EventTypeAttachmentNoteAdded   EventType = "attachment_note_added"
+	EventTypeServiceNoteAdded      EventType = "service_note_added"
+	EventTypeGeoNoteAdded          EventType = "geo_note_added"
+	EventTypeSiteVisitNoteAdded    EventType = "site_visit_note_added"
+	EventTypeKeyActionCompleted    EventType = "key_action_completed"
+	EventTypeRobotReplied          EventType = "robot_replied"
+	EventTypeIntentIdentified      EventType = "intent_identified"
+	EventTypeNPSRateAdded          EventType = "nps_rate_added"
+	EventTypeLinkFollowed          EventType = "link_followed"
+	EventTypeTransactionAdded      EventType = "transaction_added"
+)
+
+var knownEventTypes = map[EventType]bool{
+	EventTypeLeadAdded:             true,
+	EventTypeLeadDeleted:           true,
+	EventTypeLeadRestored:          true,
+	EventTypeLeadStatusChanged:     true,
+	EventTypeLeadLinked:            true,
+	EventTypeLeadUnlinked:          true,
+	EventTypeContactAdded:          true,
+	EventTypeContactDeleted:        true,
+	EventTypeContactRestored:       true,
+	EventTypeContactLinked:         true,
+	EventTypeContactUnlinked:       true,
+	EventTypeCompanyAdded:          true,
+	EventTypeCompanyDeleted:        true,
+	EventTypeCompanyRestored:       true,
+	EventTypeCompanyLinked:         true,
+	EventTypeCompanyUnlinked:       true,
+	EventTypeCustomerAdded:         true,
+	EventTypeCustomerDeleted:       true,
+	EventTypeCustomerStatusChanged: true,
+	EventTypeCustomerLinked:        true,
+	EventTypeCustomerUnlinked:      true,
+	EventTypeTaskAdded:             true,
+	EventTypeTaskDeleted:           true,
+	EventTypeTaskCompleted:         true,
+	EventTypeTaskTypeChanged:       true,
+	EventTypeTaskTextChanged:       true,
+	EventTypeTaskDeadlineChanged:   true,
+	EventTypeTaskResultAdded:       true,
+	EventTypeIncomingCall:          true,
+	EventTypeOutgoingCall:          true,
+	EventTypeIncomingChatMessage:   true,
+	EventTypeOutgoingChatMessage:   true,
+	EventTypeEntityDirectMessage:   true,
+	EventTypeIncomingSMS:           true,
+	EventTypeOutgoingSMS:           true,
+	EventTypeEntityTagAdded:        true,
+	EventTypeEntityTagDeleted:      true,
+	EventTypeEntityLinked:          true,
+	EventTypeEntityUnlinked:        true,
+	EventTypeEntityMerged:          true,
+	EventTypeEntityResponsible:     true,
+	EventTypeSaleFieldChanged:      true,
+	EventTypeNameFieldChanged:      true,
+	EventTypeLTVFieldChanged:       true,
+	EventTypeCommonNoteAdded:       true,
+	EventTypeCommonNoteDeleted:     true,
+	EventTypeAttachmentNoteAdded:   true,
+	EventTypeServiceNoteAdded:      true,
+	EventTypeGeoNoteAdded:          true,
+	EventTypeSiteVisitNoteAdded:    true,
+	EventTypeKeyActionCompleted:    true,
+	EventTypeRobotReplied:          true,
+	EventTypeIntentIdentified:      true,
+	EventTypeNPSRateAdded:          true,
+	EventTypeLinkFollowed:          true,
+	EventTypeTransactionAdded:      true,
+}
+
+var customFieldEventTypePattern = regexp.MustCompile(`^custom_field_\d+_value_changed$`)
+
+// CustomFieldValueChanged returns the event type of value changes of a
+// custom field
+func CustomFieldValueChanged(fieldID int) EventType {
+	return EventType(fmt.Sprintf("custom_field_%d_value_changed", fieldID))
+}
+
+// IsKnown reports whether t is one of the event types known to this package
+func (t EventType) IsKnown() bool {
+	return knownEventTypes[t] || customFieldEventTypePattern.MatchString(string(t))
+}
+
+// Event represents an AmoCRM event (an entry of the account activity log)
+type Event struct {
+	ID          string                   `json:"id"`
+	Type        EventType                `json:"type"`
+	EntityID    int                      `json:"entity_id"`
+	EntityType  string                   `json:"entity_type"` // lead, contact, company, customer, task
+	CreatedBy   int                      `json:"created_by"`
+	CreatedAt   int64                    `json:"created_at"`
+	ValueAfter  []map[string]interface{} `json:"value_after,omitempty"`
+	ValueBefore []map[string]interface{} `json:"value_before,omitempty"`
+	AccountID   int                      `json:"account_id,omitempty"`
+	Embedded    *EventEmbedded           `json:"_embedded,omitempty"`
+}
+
+// EventEmbedded represents embedded event data
+type EventEmbedded struct {
+	Entity *EventEntity `json:"entity,omitempty"`
+}
+
+// EventEntity represents the entity of an event, returned with the
+// *_name values of the with parameter
+type EventEntity struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Links *Links `json:"_links,omitempty"`
+}
+
+// EntityName returns the name of the event entity if it was requested via With
+func (e *Event) EntityName() string {
+	if e.Embedded == nil || e.Embedded.Entity == nil {
+		return ""
+	}
+	return e.Embedded.Entity.Name
+}
+
+// ResponsibleUserIDs returns the responsible user before and after an
+// entity_responsible_changed event. Both are 0 for other event types.
+func (e *Event) ResponsibleUserIDs() (before, after int) {
+	if e.Type != EventTypeEntityResponsible {
+		return 0, 0
+	}
+	return responsibleUserID(e.ValueBefore), responsibleUserID(e.ValueAfter)
+}
+
+// responsibleUserID reads [{"responsible_user": {"id": ...}}] event values
+func responsibleUserID(values []map[string]interface{}) int {
+	for _, value := range values {
+		user, ok := value["responsible_user"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := user["id"].(float64); ok {
+			return int(id)
+		}
+	}
+	return 0
+}
+
+// EventsService handles communication with event-related methods
+type EventsService struct {
+	client *Client
+}
+
+// EventsResponse represents the API response for events list
+type EventsResponse struct {
+	Embedded struct {
+		Events []Event `json:"events"`
+	} `json:"_embedded"`
+	Links     Links `json:"_links"`
+	Page      int   `json:"_page,omitempty"`
+	PageCount int   `json:"_page_count,omitempty"`
+}
+
+// TotalPages returns the page count reported by the API, if any. When it
+// is available there is no need to probe pages with FindTotalPages.
+func (r *EventsResponse) TotalPages() (int, bool) {
+	return r.PageCount, r.PageCount > 0
+}
+
+// EventsFilter represents filter options for listing events
+type EventsFilter struct {
+	Limit     int
+	Page      int
+	With      string   // comma-separated list: lead_name, contact_name, company_name, customer_name, catalog_element_name
+	Entity    []string // lead, contact, company, customer, task
+	EntityID  []int
+	Type      []EventType // validated against the known event types
+	RawType   []string    // sent as is, for types not known to this package
+	CreatedBy []int
+	CreatedAt map[string]int64 // from, to
+}
+
+// List retrieves a list of events
+func (s *EventsService) List(ctx context.Context, filter *EventsFilter) ([]Event, error) {
+	resp, err := s.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Events, nil
+}
+
+// ListWithResponse retrieves a list of events with pagination links
+func (s *EventsService) ListWithResponse(ctx context.Context, filter *EventsFilter) (*EventsResponse, error) {
+	path := "/events"
+
+	if filter != nil {
+		for _, eventType := range filter.Type {
+			if !eventType.IsKnown() {
+				return nil, fmt.Errorf("unknown event type %q; use RawType to filter by types not known to this package", eventType)
+			}
+		}
+
+		path += "?"
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		if filter.With != "" {
+			path += fmt.Sprintf("with=%s&", filter.With)
+		}
+		for _, entity := range filter.Entity {
+			path += fmt.Sprintf("filter[entity][]=%s&", entity)
+		}
+		for _, entityID := range filter.EntityID {
+			path += fmt.Sprintf("filter[entity_id][]=%d&", entityID)
+		}
+		for _, eventType := range filter.Type {
+			path += fmt.Sprintf("filter[type][]=%s&", eventType)
+		}
+		for _, eventType := range filter.RawType {
+			path += fmt.Sprintf("filter[type][]=%s&", eventType)
+		}
+		for _, userID := range filter.CreatedBy {
+			path += fmt.Sprintf("filter[created_by][]=%d&", userID)
+		}
+		path += rangeFilter("created_at", filter.CreatedAt)
+	}
+
+	var resp EventsResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ForEachEvent calls fn for every event matching filter, following
+// pagination page by page so memory stays flat for long exports. Use
+// filter.CreatedAt to bound the exported date range. Iteration stops at the
+// first error returned by fn or when ctx is cancelled.
+func (s *EventsService) ForEachEvent(ctx context.Context, filter *EventsFilter, fn func(Event) error) error {
+	f := EventsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	if f.Page == 0 {
+		f.Page = 1
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, err := s.ListWithResponse(ctx, &f)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range resp.Embedded.Events {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+
+		if !resp.Links.HasNext() || len(resp.Embedded.Events) == 0 {
+			return nil
+		}
+		f.Page++
+	}
+}
+
+// ContactChange describes a contact that changed since a sync cursor
+type ContactChange struct {
+	ContactID int
+	Deleted   bool
+	Contact   *Contact // nil when Deleted
+	ChangedAt int64
+}
+
+// SyncContacts streams contacts changed since the given created_at cursor.
+// Every page of contact events is resolved to full contacts with one
+// GetByIDs call; deleted contacts are emitted by ID without a fetch.
+// Changes of a page are passed to fn oldest first.
+//
+// The returned cursor is the ChangedAt of the last change fn accepted, also
+// when an error is returned, and can be passed to the next call. The cursor
+// is inclusive, so delivery is at-least-once: changes at exactly the cursor
+// time are passed to fn again on resume.
+func (s *EventsService) SyncContacts(ctx context.Context, since int64, fn func(ContactChange) error) (int64, error) {
+	cursor := since
+	filter := &EventsFilter{
+		Limit:     100,
+		Page:      1,
+		Entity:    []string{"contact"},
+		CreatedAt: map[string]int64{"from": since},
+	}
+
+	for {
+		resp, err := s.ListWithResponse(ctx, filter)
+		if err != nil {
+			return cursor, err
+		}
+
+		// Keep the latest event per contact within the page
+		latest := make(map[int]Event)
+		var order []int
+		for _, event := range resp.Embedded.Events {
+			prev, seen := latest[event.EntityID]
+			if !seen {
+				order = append(order, event.EntityID)
+			}
+			if !seen || event.CreatedAt >= prev.CreatedAt {
+				latest[event.EntityID] = event
+			}
+		}
+
+		// Deliver oldest first, so the cursor never passes an undelivered
+		// change
+		sort.SliceStable(order, func(i, j int) bool {
+			return latest[order[i]].CreatedAt < latest[order[j]].CreatedAt
+		})
+
+		var fetchIDs []int
+		for _, id := range order {
+			if latest[id].Type != EventTypeContactDeleted {
+				fetchIDs = append(fetchIDs, id)
+			}
+		}
+
+		contacts, err := s.client.Contacts.GetByIDs(ctx, fetchIDs)
+		if err != nil {
+			return cursor, err
+		}
+
+		byID := make(map[int]*Contact, len(contacts))
+		for i := range contacts {
+			byID[contacts[i].ID] = &contacts[i]
+		}
+
+		for _, id := range order {
+			event := latest[id]
+			change := ContactChange{
+				ContactID: id,
+				Deleted:   event.Type == EventTypeContactDeleted,
+				ChangedAt: event.CreatedAt,
+			}
+			if !change.Deleted {
+				contact, ok := byID[id]
+				if !ok {
+					// Deleted after the event was recorded
+					change.Deleted = true
+				}
+				change.Contact = contact
+			}
+			if err := fn(change); err != nil {
+				return cursor, err
+			}
+			if change.ChangedAt > cursor {
+				cursor = change.ChangedAt
+			}
+		}
+
+		if !resp.Links.HasNext() {
+			return cursor, nil
+		}
+		filter.Page++
+	}
+}
+
+// ResponsiblePeriod is a period during which a user was responsible for an
+// entity. From is 0 for the owner before the first recorded change, To is 0
+// for the current owner.
+type ResponsiblePeriod struct {
+	UserID int
+	From   int64
+	To     int64
+}
+
+// LeadResponsibleTimeline returns who owned a lead over time, oldest period
+// first, built from the entity_responsible_changed events of the lead. A
+// lead whose owner never changed has no periods.
+func (s *EventsService) LeadResponsibleTimeline(ctx context.Context, leadID int) ([]ResponsiblePeriod, error) {
+	if leadID == 0 {
+		return nil, fmt.Errorf("lead ID is required")
+	}
+
+	filter := &EventsFilter{
+		Limit:    100,
+		Entity:   []string{"lead"},
+		EntityID: []int{leadID},
+		Type:     []EventType{EventTypeEntityResponsible},
+	}
+
+	var events []Event
+	err := s.ForEachEvent(ctx, filter, func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt < events[j].CreatedAt
+	})
+
+	var periods []ResponsiblePeriod
+	for i, event := range events {
+		before, after := event.ResponsibleUserIDs()
+		if i == 0 {
+			periods = append(periods, ResponsiblePeriod{UserID: before})
+		}
+		periods[len(periods)-1].To = event.CreatedAt
+		periods = append(periods, ResponsiblePeriod{UserID: after, From: event.CreatedAt})
+	}
+
+	return periods, nil
+}
@@ -0,0 +1,296 @@
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEventsService_SyncContacts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v4/events":
+			if got := r.URL.Query().Get("filter[created_at][from]"); got != "1000" {
+				t.Errorf("Expected created_at cursor 1000, got '%s'", got)
+			}
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{
+					"_links": {"next": {"href": "https://test.amocrm.ru/api/v4/events?page=2"}},
+					"_embedded": {"events": [
+						{"id": "a", "type": "contact_added", "entity_id": 1, "entity_type": "contact", "created_at": 1001},
+						{"id": "b", "type": "name_field_changed", "entity_id": 1, "entity_type": "contact", "created_at": 1002}
+					]}
+				}`))
+				return
+			}
+			w.Write([]byte(`{
+				"_embedded": {"events": [
+					{"id": "c", "type": "contact_deleted", "entity_id": 2, "entity_type": "contact", "created_at": 1005}
+				]}
+			}`))
+
+		case "/api/v4/contacts":
+			if got := r.URL.Query()["filter[id][]"]; len(got) != 1 || got[0] != "1" {
+				t.Errorf("Expected to fetch contact 1 only, got %v", got)
+			}
+			w.Write([]byte(`{"_embedded": {"contacts": [{"id": 1, "name": "Ivan"}]}}`))
+
+		default:
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+	})
+
+	var changes []ContactChange
+	cursor, err := client.Events.SyncContacts(context.Background(), 1000, func(c ContactChange) error {
+		changes = append(changes, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cursor != 1005 {
+		t.Errorf("Expected cursor 1005, got %d", cursor)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(changes))
+	}
+
+	if changes[0].Contact == nil || changes[0].Contact.Name != "Ivan" {
+		t.Errorf("Expected resolved contact 'Ivan', got %+v", changes[0])
+	}
+
+	if !changes[1].Deleted || changes[1].ContactID != 2 || changes[1].Contact != nil {
+		t.Errorf("Expected deleted contact 2, got %+v", changes[1])
+	}
+}
+
+// syncContactsServer serves the contact events at or after the created_at
+// cursor on a single page and resolves every requested contact
+func syncContactsServer(t *testing.T, events map[int]int64, contactsStatus int) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v4/events":
+			from, _ := strconv.ParseInt(r.URL.Query().Get("filter[created_at][from]"), 10, 64)
+			var page []string
+			for id, createdAt := range events {
+				if createdAt >= from {
+					page = append(page, fmt.Sprintf(`{"type": "name_field_changed", "entity_id": %d, "entity_type": "contact", "created_at": %d}`, id, createdAt))
+				}
+			}
+			fmt.Fprintf(w, `{"_embedded": {"events": [%s]}}`, strings.Join(page, ","))
+
+		case "/api/v4/contacts":
+			if contactsStatus != http.StatusOK {
+				w.WriteHeader(contactsStatus)
+				return
+			}
+			var contacts []string
+			for _, id := range r.URL.Query()["filter[id][]"] {
+				contacts = append(contacts, `{"id": `+id+`}`)
+			}
+			fmt.Fprintf(w, `{"_embedded": {"contacts": [%s]}}`, strings.Join(contacts, ","))
+
+		default:
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+	})
+}
+
+func TestEventsService_SyncContactsResume(t *testing.T) {
+	client := syncContactsServer(t, map[int]int64{1: 1001, 2: 1003, 3: 1002}, http.StatusOK)
+
+	failure := errors.New("store unavailable")
+	var delivered []int
+	cursor, err := client.Events.SyncContacts(context.Background(), 1000, func(c ContactChange) error {
+		if c.ContactID == 2 {
+			return failure
+		}
+		delivered = append(delivered, c.ContactID)
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the fn error, got %v", err)
+	}
+	if cursor != 1002 {
+		t.Errorf("Expected the cursor of the last delivered change 1002, got %d", cursor)
+	}
+	if len(delivered) != 2 || delivered[0] != 1 || delivered[1] != 3 {
+		t.Errorf("Expected contacts [1 3] delivered oldest first, got %v", delivered)
+	}
+
+	// The cursor is inclusive: contact 3 is delivered again
+	delivered = nil
+	cursor, err = client.Events.SyncContacts(context.Background(), cursor, func(c ContactChange) error {
+		delivered = append(delivered, c.ContactID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cursor != 1003 {
+		t.Errorf("Expected cursor 1003, got %d", cursor)
+	}
+	if len(delivered) != 2 || delivered[0] != 3 || delivered[1] != 2 {
+		t.Errorf("Expected contacts [3 2] on resume, got %v", delivered)
+	}
+}
+
+func TestEventsService_SyncContactsFetchError(t *testing.T) {
+	client := syncContactsServer(t, map[int]int64{1: 1001}, http.StatusInternalServerError)
+
+	cursor, err := client.Events.SyncContacts(context.Background(), 1000, func(c ContactChange) error {
+		t.Errorf("Unexpected change %+v", c)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected the contacts fetch error")
+	}
+	if cursor != 1000 {
+		t.Errorf("Expected the cursor to stay at 1000, got %d", cursor)
+	}
+}
+
+func TestEventsService_ListWithEntityName(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("with"); got != "lead_name" {
+			t.Errorf("Expected with=lead_name, got '%s'", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"events": [{
+			"id": "a",
+			"type": "lead_status_changed",
+			"entity_id": 5,
+			"entity_type": "lead",
+			"_embedded": {"entity": {"id": 5, "name": "Acme Deal"}}
+		}]}}`))
+	})
+
+	events, err := client.Events.List(context.Background(), &EventsFilter{With: "lead_name"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(events) != 1 || events[0].EntityName() != "Acme Deal" {
+		t.Errorf("Expected entity name 'Acme Deal', got %+v", events)
+	}
+}
+
+func TestEventsService_ForEachEvent(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		if r.URL.Query().Get("filter[created_at][to]") != "2000" {
+			t.Errorf("Expected created_at range in '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"_links": {"next": {"href": "next"}}, "_embedded": {"events": [{"id": "a"}, {"id": "b"}]}}`))
+		case "2":
+			w.Write([]byte(`{"_embedded": {"events": [{"id": "c"}]}}`))
+		default:
+			t.Errorf("Unexpected page '%s'", r.URL.Query().Get("page"))
+		}
+	})
+
+	var ids []string
+	filter := &EventsFilter{CreatedAt: map[string]int64{"from": 1000, "to": 2000}}
+	err := client.Events.ForEachEvent(context.Background(), filter, func(e Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(ids) != 3 || len(pages) != 2 {
+		t.Errorf("Expected 3 events over 2 pages, got %v over %v", ids, pages)
+	}
+
+	if filter.Page != 0 {
+		t.Error("Caller filter should not be modified")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.Events.ForEachEvent(ctx, nil, func(Event) error { return nil }); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestEventsService_ListValidatesType(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if _, err := client.Events.List(ctx, &EventsFilter{Type: []EventType{"lead_addded"}}); err == nil {
+		t.Error("Expected error for unknown event type")
+	}
+	if query != "" {
+		t.Errorf("Expected no request for unknown event type, got '%s'", query)
+	}
+
+	_, err := client.Events.List(ctx, &EventsFilter{
+		Type:    []EventType{EventTypeLeadAdded, CustomFieldValueChanged(42)},
+		RawType: []string{"brand_new_type"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "filter[type][]=lead_added&filter[type][]=custom_field_42_value_changed&filter[type][]=brand_new_type&"
+	if query != expected {
+		t.Errorf("Expected query '%s', got '%s'", expected, query)
+	}
+}
+
+func TestEventsService_LeadResponsibleTimeline(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("filter[entity_id][]") != "10" || query.Get("filter[type][]") != "entity_responsible_changed" {
+			t.Errorf("Unexpected query '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"events": [
+			{"id": "b", "type": "entity_responsible_changed", "entity_id": 10, "created_at": 2000,
+				"value_before": [{"responsible_user": {"id": 2}}], "value_after": [{"responsible_user": {"id": 3}}]},
+			{"id": "a", "type": "entity_responsible_changed", "entity_id": 10, "created_at": 1000,
+				"value_before": [{"responsible_user": {"id": 1}}], "value_after": [{"responsible_user": {"id": 2}}]}
+		]}}`))
+	})
+
+	periods, err := client.Events.LeadResponsibleTimeline(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []ResponsiblePeriod{
+		{UserID: 1, From: 0, To: 1000},
+		{UserID: 2, From: 1000, To: 2000},
+		{UserID: 3, From: 2000, To: 0},
+	}
+	if len(periods) != len(expected) {
+		t.Fatalf("Expected %d periods, got %+v", len(expected), periods)
+	}
+	for i := range expected {
+		if periods[i] != expected[i] {
+			t.Errorf("Expected period %d to be %+v, got %+v", i, expected[i], periods[i])
+		}
+	}
+}
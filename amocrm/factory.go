@@ -0,0 +1,77 @@
+package amocrm
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// ClientFactory creates clients for many accounts that share one HTTP
+// client, so connections are pooled across accounts. AmoCRM limits
+// requests per account, so every client still gets its own rate limiter.
+//
+//	factory := amocrm.NewClientFactory(
+//		amocrm.WithOAuth2("client-id", "client-secret", "redirect-uri"),
+//		amocrm.WithTokenStorage(storage),
+//		amocrm.WithTransportTuning(100, 10, 90*time.Second),
+//	)
+//	client := factory.NewClientForAccount("testsubdomain")
+type ClientFactory struct {
+	httpClient *http.Client
+	opts       []ClientOption
+}
+
+// NewClientFactory returns a factory applying opts to every client. The
+// HTTP client is resolved once from opts (WithHTTPClient, or the default
+// client with WithTimeout and WithTransportTuning applied) and shared.
+func NewClientFactory(opts ...ClientOption) *ClientFactory {
+	probe := &Client{
+		httpClient: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(probe)
+	}
+
+	httpClient := probe.httpClient
+	if !probe.customHTTPClient {
+		transport := probe.transport
+		if transport == nil {
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		httpClient.Transport = transport
+	}
+
+	return &ClientFactory{httpClient: httpClient, opts: opts}
+}
+
+// NewClientForAccount creates a client for the account subdomain using the
+// factory options and the shared HTTP client. opts are applied last and
+// may override factory options per account; they must not change the HTTP
+// client (WithHTTPClient, WithTimeout), which is shared by all accounts.
+func (f *ClientFactory) NewClientForAccount(subdomain string, opts ...ClientOption) *Client {
+	all := make([]ClientOption, 0, len(f.opts)+len(opts)+2)
+	all = append(all, f.opts...)
+	all = append(all, WithHTTPClient(f.httpClient), WithSubdomain(subdomain))
+	all = append(all, opts...)
+
+	return NewClient(all...)
+}
+
+// WithSharedLimiter makes the client wait for limiter instead of a rate
+// limiter of its own, e.g. to share one limit between several clients of
+// the same account.
+//
+// The limiter's rate at the time WithSharedLimiter is called is the
+// configured rate: with WithAdaptiveRateLimit, every client sharing the
+// limiter tightens it for all of them and restores that rate, even when
+// the client is created while the limiter is tightened. Create the option
+// once, before the limiter is in use, and reuse it for every client.
+func WithSharedLimiter(limiter *rate.Limiter) ClientOption {
+	base := limiter.Limit()
+	return func(c *Client) {
+		c.rateLimiter = limiter
+		c.baseRateLimit = base
+	}
+}
@@ -0,0 +1,68 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestClientFactory(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	factory := NewClientFactory(
+		WithPermanentToken("test-token"),
+		WithTimeout(5*time.Second),
+		WithTransportTuning(10, 5, time.Minute),
+	)
+
+	first := factory.NewClientForAccount("first", WithBaseURL(server.URL+"/first/api/v4"))
+	second := factory.NewClientForAccount("second", WithBaseURL(server.URL+"/second/api/v4"))
+
+	if first.httpClient != second.httpClient {
+		t.Error("Expected accounts to share the HTTP client")
+	}
+	if transport, ok := first.httpClient.Transport.(*http.Transport); !ok || transport.MaxIdleConnsPerHost != 5 {
+		t.Errorf("Expected the tuned transport, got %v", first.httpClient.Transport)
+	}
+	if first.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout 5s, got %v", first.httpClient.Timeout)
+	}
+	if first.rateLimiter == second.rateLimiter {
+		t.Error("Expected a rate limiter per account")
+	}
+	if first.accountDomain() != "first.amocrm.ru" || second.accountDomain() != "second.amocrm.ru" {
+		t.Errorf("Unexpected account domains %s, %s", first.accountDomain(), second.accountDomain())
+	}
+
+	ctx := context.Background()
+	if _, err := first.Leads.List(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.Leads.List(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != 2 || paths[0] != "/first/api/v4/leads" || paths[1] != "/second/api/v4/leads" {
+		t.Errorf("Unexpected request paths %v", paths)
+	}
+}
+
+func TestWithSharedLimiter(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Limit(5), 2)
+
+	first := NewClient(WithSubdomain("test"), WithSharedLimiter(limiter))
+	second := NewClient(WithSubdomain("test"), WithSharedLimiter(limiter))
+
+	if first.rateLimiter != limiter || second.rateLimiter != limiter {
+		t.Error("Expected both clients to use the shared limiter")
+	}
+}
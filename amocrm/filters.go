@@ -0,0 +1,137 @@
+package amocrm
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// maxIDsPerRequest is the largest number of IDs AmoCRM accepts in a single
+// filter[id][] list or bulk request
+const maxIDsPerRequest = 250
+
+// searchQuery renders a search string as a percent-encoded query=X&
+// parameter, so spaces, ampersands and plus signs survive the request
+func searchQuery(query string) string {
+	return url.Values{"query": {query}}.Encode() + "&"
+}
+
+// listFilter renders an ID list filter as
+// filter[field][]=X&filter[field][]=Y&
+func listFilter(field string, ids []int) string {
+	var query string
+	for _, id := range ids {
+		query += fmt.Sprintf("filter[%s][]=%d&", field, id)
+	}
+	return query
+}
+
+// listByIDChunks calls list once per chunk of at most maxIDsPerRequest IDs
+// and merges the results
+func listByIDChunks[T any](ids []int, list func(chunk []int) ([]T, error)) ([]T, error) {
+	var all []T
+	for start := 0; start < len(ids); start += maxIDsPerRequest {
+		end := start + maxIDsPerRequest
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		items, err := list(ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// rangeFilter renders a time range filter as
+// filter[field][from]=X&filter[field][to]=Y&. Only the "from" and "to"
+// keys of r are used; missing keys are omitted.
+func rangeFilter(field string, r map[string]int64) string {
+	var query string
+	if from, ok := r["from"]; ok {
+		query += fmt.Sprintf("filter[%s][from]=%d&", field, from)
+	}
+	if to, ok := r["to"]; ok {
+		query += fmt.Sprintf("filter[%s][to]=%d&", field, to)
+	}
+	return query
+}
+
+// customFieldsFilter renders exact custom field value filters as
+// filter[custom_fields_values][fieldID][]=value&, one parameter per value.
+// Field IDs are sorted so the query is stable.
+func customFieldsFilter(fields map[int][]string) string {
+	ids := make([]int, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var query string
+	for _, id := range ids {
+		for _, value := range fields[id] {
+			query += fmt.Sprintf("filter[custom_fields_values][%d][]=%s&", id, url.QueryEscape(value))
+		}
+	}
+	return query
+}
+
+// Sort directions for list ordering
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// OrderField is one sort key of a list request
+type OrderField struct {
+	Field     string
+	Direction string // OrderAsc or OrderDesc; asc when empty
+}
+
+// orderFilter renders sort keys as order[field]=direction&, keeping their
+// order so later keys act as tie-breakers
+func orderFilter(fields []OrderField) string {
+	var query string
+	for _, f := range fields {
+		direction := f.Direction
+		if direction == "" {
+			direction = OrderAsc
+		}
+		query += fmt.Sprintf("order[%s]=%s&", f.Field, direction)
+	}
+	return query
+}
+
+// mapFilter renders free-form filter parameters as filter[key]=value&, or
+// filter[key][]=value& per element for slice values. Keys are sorted so
+// the query is stable.
+func mapFilter(filter map[string]interface{}) string {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var query string
+	for _, key := range keys {
+		switch v := filter[key].(type) {
+		case []int:
+			for _, item := range v {
+				query += fmt.Sprintf("filter[%s][]=%d&", key, item)
+			}
+		case []string:
+			for _, item := range v {
+				query += fmt.Sprintf("filter[%s][]=%s&", key, url.QueryEscape(item))
+			}
+		case []interface{}:
+			for _, item := range v {
+				query += fmt.Sprintf("filter[%s][]=%s&", key, url.QueryEscape(fmt.Sprint(item)))
+			}
+		default:
+			query += fmt.Sprintf("filter[%s]=%s&", key, url.QueryEscape(fmt.Sprint(v)))
+		}
+	}
+	return query
+}
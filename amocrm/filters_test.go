@@ -0,0 +1,255 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRangeFilter(t *testing.T) {
+	tests := []struct {
+		r    map[string]int64
+		want string
+	}{
+		{nil, ""},
+		{map[string]int64{"from": 1}, "filter[updated_at][from]=1&"},
+		{map[string]int64{"to": 2}, "filter[updated_at][to]=2&"},
+		{map[string]int64{"from": 1, "to": 2}, "filter[updated_at][from]=1&filter[updated_at][to]=2&"},
+	}
+
+	for _, tt := range tests {
+		if got := rangeFilter("updated_at", tt.r); got != tt.want {
+			t.Errorf("rangeFilter(%v) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestRangeFiltersAcrossServices(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+" "+r.URL.Query().Get("filter[updated_at][from]")+"-"+r.URL.Query().Get("filter[updated_at][to]"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	window := map[string]int64{"from": 10, "to": 20}
+
+	if _, err := client.Leads.List(ctx, &LeadsFilter{UpdatedAt: window}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Contacts.List(ctx, &ContactsFilter{UpdatedAt: window}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Tasks.List(ctx, &TasksFilter{UpdatedAt: window}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/api/v4/leads 10-20", "/api/v4/contacts 10-20", "/api/v4/tasks 10-20"}
+	for i := range want {
+		if i >= len(queries) || queries[i] != want[i] {
+			t.Errorf("Request %d: got %v, want %q", i, queries, want[i])
+		}
+	}
+
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter[created_at][from]") != "10" || r.URL.Query().Get("filter[created_at][to]") != "20" {
+			t.Errorf("Unexpected events query '%s'", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if _, err := client.Events.List(ctx, &EventsFilter{CreatedAt: window}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchQueryEscaping(t *testing.T) {
+	var rawQueries, queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		rawQueries = append(rawQueries, r.URL.RawQuery)
+		queries = append(queries, r.URL.Query().Get("query"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	client.Contacts.List(ctx, &ContactsFilter{Query: "Иван & Co"})
+	client.Leads.ListWithResponse(ctx, &LeadsFilter{Query: "Иван & Co"})
+
+	expected := "query=%D0%98%D0%B2%D0%B0%D0%BD+%26+Co&"
+	for i := range rawQueries {
+		if rawQueries[i] != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, rawQueries[i])
+		}
+		if queries[i] != "Иван & Co" {
+			t.Errorf("Expected decoded query 'Иван & Co', got '%s'", queries[i])
+		}
+	}
+	if len(rawQueries) != 2 {
+		t.Errorf("Expected 2 requests, got %d", len(rawQueries))
+	}
+}
+
+func TestContactsCustomFieldsFilter(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	filter := &ContactsFilter{
+		CustomFields: map[int][]string{
+			200: {"+79990000001", "+79990000002"},
+			100: {"a&b"},
+		},
+	}
+	if _, err := client.Contacts.List(context.Background(), filter); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "filter[custom_fields_values][100][]=a%26b&" +
+		"filter[custom_fields_values][200][]=%2B79990000001&" +
+		"filter[custom_fields_values][200][]=%2B79990000002&"
+	if query != want {
+		t.Errorf("Expected query %q, got %q", want, query)
+	}
+}
+
+func TestLeadsFilterIDsChunked(t *testing.T) {
+	ids := make([]int, 300)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	tests := []struct {
+		name   string
+		filter *LeadsFilter
+	}{
+		{"default page", &LeadsFilter{IDs: ids}},
+		{"limit and page ignored", &LeadsFilter{IDs: ids, Limit: 50, Page: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunks []int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				ids := query["filter[id][]"]
+				chunks = append(chunks, len(ids))
+
+				// AmoCRM returns 50 entities per page unless limit says otherwise
+				limit, page := 50, 1
+				if l := query.Get("limit"); l != "" {
+					limit, _ = strconv.Atoi(l)
+				}
+				if p := query.Get("page"); p != "" {
+					page, _ = strconv.Atoi(p)
+				}
+
+				var leads []string
+				for i := (page - 1) * limit; i < page*limit && i < len(ids); i++ {
+					leads = append(leads, `{"id": `+ids[i]+`}`)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"_embedded": {"leads": [` + strings.Join(leads, ",") + `]}}`))
+			})
+
+			leads, err := client.Leads.List(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(chunks) != 2 || chunks[0] != 250 || chunks[1] != 50 {
+				t.Errorf("Expected chunks of 250 and 50 IDs, got %v", chunks)
+			}
+			if len(leads) != len(ids) {
+				t.Fatalf("Expected %d leads, got %d", len(ids), len(leads))
+			}
+			for i, lead := range leads {
+				if lead.ID != ids[i] {
+					t.Errorf("Expected lead %d at position %d, got %d", ids[i], i, lead.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestContactsFilterIDs(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Contacts.List(context.Background(), &ContactsFilter{IDs: []int{3, 4}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "filter[id][]=3&filter[id][]=4&"; query != want {
+		t.Errorf("Expected query %q, got %q", want, query)
+	}
+}
+
+func TestResponsibleUserFilterAcrossServices(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	users := []int{7, 8}
+
+	if _, err := client.Leads.List(ctx, &LeadsFilter{ResponsibleUserID: users}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Contacts.List(ctx, &ContactsFilter{ResponsibleUserID: users}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Companies.List(ctx, &CompaniesFilter{ResponsibleUserID: users}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "filter[responsible_user_id][]=7&filter[responsible_user_id][]=8&"
+	for _, query := range queries {
+		if query != want {
+			t.Errorf("Expected query %q, got %q", want, query)
+		}
+	}
+	if len(queries) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(queries))
+	}
+}
+
+func TestOrderDirectionAcrossServices(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if _, err := client.Leads.List(ctx, &LeadsFilter{Order: "created_at", OrderDirection: OrderDesc}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Contacts.List(ctx, &ContactsFilter{Order: "created_at", OrderDirection: OrderDesc}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Companies.List(ctx, &CompaniesFilter{Order: "created_at", OrderDirection: OrderDesc}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Companies.List(ctx, &CompaniesFilter{Order: "id"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"order[created_at]=desc&", "order[created_at]=desc&", "order[created_at]=desc&", "order[id]=asc&"}
+	if len(queries) != len(want) {
+		t.Fatalf("Expected %d requests, got %d", len(want), len(queries))
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Errorf("Request %d: expected query %q, got %q", i, want[i], queries[i])
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// CatalogTypeInvoices is the type of the built-in invoices catalog
+const CatalogTypeInvoices = "invoices"
+
+// Invoice statuses, the values of the BILL_STATUS field
+const (
+	InvoiceStatusCreated       = "Создан"
+	InvoiceStatusPaid          = "Оплачен"
+	InvoiceStatusPartiallyPaid = "Частично оплачен"
+	InvoiceStatusCanceled      = "Отменён"
+)
+
+// Field codes of the invoices catalog
+const (
+	invoiceFieldStatus      = "BILL_STATUS"
+	invoiceFieldItems       = "ITEMS"
+	invoiceFieldPrice       = "BILL_PRICE"
+	invoiceFieldPaymentDate = "BILL_PAYMENT_DATE"
+	invoiceFieldComment     = "BILL_COMMENT"
+)
+
+// Invoice represents an element of the invoices catalog
+type Invoice struct {
+	ID          int
+	Name        string
+	Status      string // one of the InvoiceStatus* values
+	Items       []InvoiceItem
+	Price       float64
+	PaymentDate int64
+	Comment     string
+
+	// Element is the underlying catalog element of a fetched invoice
+	Element *CatalogElement
+}
+
+// InvoiceItem represents a line item of an invoice
+type InvoiceItem struct {
+	SKU         string           `json:"sku,omitempty"`
+	Description string           `json:"description"`
+	UnitPrice   float64          `json:"unit_price"`
+	Quantity    float64          `json:"quantity"`
+	UnitType    string           `json:"unit_type,omitempty"`
+	Discount    *InvoiceDiscount `json:"discount,omitempty"`
+	VatRateID   int              `json:"vat_rate_id,omitempty"`
+	ExternalUID string           `json:"external_uid,omitempty"`
+}
+
+// InvoiceDiscount represents a line item discount
+type InvoiceDiscount struct {
+	Type  string  `json:"type"` // amount or percentage
+	Value float64 `json:"value"`
+}
+
+// InvoicesService wraps the invoices catalog with an invoice-shaped API
+type InvoicesService struct {
+	client *Client
+
+	catalogMu sync.Mutex
+	catalogID int
+}
+
+// CatalogID returns the ID of the account invoices catalog. It is looked
+// up once and cached.
+func (s *InvoicesService) CatalogID(ctx context.Context) (int, error) {
+	s.catalogMu.Lock()
+	defer s.catalogMu.Unlock()
+
+	if s.catalogID != 0 {
+		return s.catalogID, nil
+	}
+
+	filter := &CatalogsFilter{Limit: 250, Page: 1}
+	for {
+		resp, err := s.client.Catalogs.ListWithResponse(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, catalog := range resp.Embedded.Catalogs {
+			if catalog.Type == CatalogTypeInvoices {
+				s.catalogID = catalog.ID
+				return catalog.ID, nil
+			}
+		}
+		if !resp.Links.HasNext() || len(resp.Embedded.Catalogs) == 0 {
+			return 0, fmt.Errorf("account has no invoices catalog")
+		}
+		filter.Page++
+	}
+}
+
+// Create creates an invoice with its line items
+func (s *InvoicesService) Create(ctx context.Context, invoice *Invoice) (*Invoice, error) {
+	if len(invoice.Items) == 0 {
+		return nil, fmt.Errorf("invoice items are required")
+	}
+
+	catalogID, err := s.CatalogID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	element := invoice.element()
+	created, err := s.client.CatalogElements.Create(ctx, catalogID, &element)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoiceFromElement(created)
+}
+
+// GetByID retrieves an invoice by ID
+func (s *InvoicesService) GetByID(ctx context.Context, id int) (*Invoice, error) {
+	catalogID, err := s.CatalogID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	element, err := s.client.CatalogElements.GetByID(ctx, catalogID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoiceFromElement(element)
+}
+
+// Status retrieves the payment status of an invoice
+func (s *InvoicesService) Status(ctx context.Context, id int) (string, error) {
+	invoice, err := s.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return invoice.Status, nil
+}
+
+// element converts the invoice to a catalog element, addressing fields by
+// code
+func (i *Invoice) element() CatalogElement {
+	element := CatalogElement{ID: i.ID, Name: i.Name}
+
+	field := func(code string, values ...FieldValue) {
+		element.CustomFieldsValues = append(element.CustomFieldsValues, CustomFieldValue{FieldCode: code, Values: values})
+	}
+
+	if i.Status != "" {
+		field(invoiceFieldStatus, FieldValue{Value: i.Status})
+	}
+	if len(i.Items) > 0 {
+		items := make([]FieldValue, len(i.Items))
+		for n, item := range i.Items {
+			items[n] = FieldValue{Value: item}
+		}
+		field(invoiceFieldItems, items...)
+	}
+	if i.Price != 0 {
+		field(invoiceFieldPrice, FieldValue{Value: i.Price})
+	}
+	if i.PaymentDate != 0 {
+		field(invoiceFieldPaymentDate, FieldValue{Value: i.PaymentDate})
+	}
+	if i.Comment != "" {
+		field(invoiceFieldComment, FieldValue{Value: i.Comment})
+	}
+
+	return element
+}
+
+// invoiceFromElement reads an invoice from a catalog element
+func invoiceFromElement(element *CatalogElement) (*Invoice, error) {
+	invoice := &Invoice{ID: element.ID, Name: element.Name, Element: element}
+
+	for _, field := range element.CustomFieldsValues {
+		if len(field.Values) == 0 {
+			continue
+		}
+		value := field.Values[0].Value
+
+		switch field.FieldCode {
+		case invoiceFieldStatus:
+			invoice.Status = fmt.Sprint(value)
+		case invoiceFieldPrice:
+			if price, ok := value.(float64); ok {
+				invoice.Price = price
+			}
+		case invoiceFieldPaymentDate:
+			if date, ok := value.(float64); ok {
+				invoice.PaymentDate = int64(date)
+			}
+		case invoiceFieldComment:
+			invoice.Comment = fmt.Sprint(value)
+		case invoiceFieldItems:
+			for _, v := range field.Values {
+				data, err := json.Marshal(v.Value)
+				if err != nil {
+					return nil, err
+				}
+				var item InvoiceItem
+				if err := json.Unmarshal(data, &item); err != nil {
+					return nil, fmt.Errorf("failed to decode invoice item: %w", err)
+				}
+				invoice.Items = append(invoice.Items, item)
+			}
+		}
+	}
+
+	return invoice, nil
+}
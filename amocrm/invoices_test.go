@@ -0,0 +1,75 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestInvoicesService_CreateAndStatus(t *testing.T) {
+	var catalogRequests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/api/v4/catalogs":
+			catalogRequests++
+			w.Write([]byte(`{"_embedded": {"catalogs": [{"id": 1, "name": "Товары", "type": "products"}, {"id": 2, "name": "Счета", "type": "invoices"}]}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/catalogs/2/elements":
+			var body struct {
+				Elements []CatalogElement `json:"elements"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			fields := body.Elements[0].CustomFieldsValues
+			if len(fields) != 2 || fields[0].FieldCode != "BILL_STATUS" || fields[1].FieldCode != "ITEMS" {
+				t.Errorf("Unexpected invoice fields: %+v", fields)
+			}
+			if fields[0].FieldID != 0 {
+				t.Errorf("Expected fields addressed by code, got ID %d", fields[0].FieldID)
+			}
+			w.Write([]byte(`{"_embedded": {"elements": [{"id": 50, "name": "Счёт №1"}]}}`))
+		case r.URL.Path == "/api/v4/catalogs/2/elements/50":
+			w.Write([]byte(`{
+				"id": 50,
+				"name": "Счёт №1",
+				"custom_fields_values": [
+					{"field_id": 10, "field_code": "BILL_STATUS", "values": [{"value": "Оплачен", "enum_id": 3}]},
+					{"field_id": 11, "field_code": "ITEMS", "values": [{"value": {"sku": "A-1", "description": "Консультация", "unit_price": 1500, "quantity": 2}}]},
+					{"field_id": 12, "field_code": "BILL_PRICE", "values": [{"value": 3000}]}
+				]
+			}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	invoice, err := client.Invoices.Create(ctx, &Invoice{
+		Name:   "Счёт №1",
+		Status: InvoiceStatusCreated,
+		Items:  []InvoiceItem{{SKU: "A-1", Description: "Консультация", UnitPrice: 1500, Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if invoice.ID != 50 {
+		t.Errorf("Expected invoice ID 50, got %d", invoice.ID)
+	}
+
+	fetched, err := client.Invoices.GetByID(ctx, 50)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetched.Status != InvoiceStatusPaid || fetched.Price != 3000 {
+		t.Errorf("Unexpected invoice: %+v", fetched)
+	}
+	if len(fetched.Items) != 1 || fetched.Items[0].Quantity != 2 || fetched.Items[0].SKU != "A-1" {
+		t.Errorf("Unexpected invoice items: %+v", fetched.Items)
+	}
+
+	if catalogRequests != 1 {
+		t.Errorf("Expected the invoices catalog to be looked up once, got %d", catalogRequests)
+	}
+}
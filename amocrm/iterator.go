@@ -0,0 +1,224 @@
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultListAllMaxPages is the number of pages ListAll reads at most when
+// the filter sets no MaxPages
+const DefaultListAllMaxPages = 100
+
+// ErrTooManyPages is returned by ListAll when the list has more pages than
+// the configured cap
+var ErrTooManyPages = errors.New("list has more pages than the ListAll cap")
+
+// Iterator walks a paginated list, fetching the next page when the
+// current one is exhausted:
+//
+//	it := client.Contacts.Iterator(ctx, &amocrm.ContactsFilter{Limit: 250})
+//	for it.Next() {
+//		contact := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page int) ([]T, bool, error)
+	page  int
+	items []T
+	index int
+	more  bool
+	err   error
+}
+
+// newIterator returns an iterator starting at page. fetch returns the
+// items of a page and whether a next page exists.
+func newIterator[T any](ctx context.Context, page int, fetch func(ctx context.Context, page int) ([]T, bool, error)) *Iterator[T] {
+	if page < 1 {
+		page = 1
+	}
+	return &Iterator[T]{ctx: ctx, fetch: fetch, page: page, index: -1, more: true}
+}
+
+// Next advances to the next item, fetching a page if needed. It returns
+// false when the list is exhausted or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.items) {
+		if !it.more {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		items, more, err := it.fetch(it.ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items, it.index, it.more = items, 0, more && len(items) > 0
+		it.page++
+	}
+
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// readAll collects the items of every page starting at page, following
+// pagination until fetch reports no next page. It reads at most maxPages
+// pages (DefaultListAllMaxPages when not positive) and fails with
+// ErrTooManyPages if more remain. On error the items collected so far are
+// returned along with it.
+func readAll[T any](ctx context.Context, page, maxPages int, fetch func(ctx context.Context, page int) ([]T, bool, error)) ([]T, error) {
+	if page < 1 {
+		page = 1
+	}
+	if maxPages <= 0 {
+		maxPages = DefaultListAllMaxPages
+	}
+
+	var all []T
+	for read := 0; ; read++ {
+		if read == maxPages {
+			return all, fmt.Errorf("%w (%d pages)", ErrTooManyPages, maxPages)
+		}
+		if err := ctx.Err(); err != nil {
+			return all, err
+		}
+
+		items, more, err := fetch(ctx, page+read)
+		if err != nil {
+			return all, err
+		}
+
+		all = append(all, items...)
+		if !more || len(items) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// Iterator returns an iterator over all contacts matching filter. The
+// filter limit is used as page size.
+func (s *ContactsService) Iterator(ctx context.Context, filter *ContactsFilter) *Iterator[Contact] {
+	f := ContactsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return newIterator(ctx, f.Page, s.pages(&f))
+}
+
+// ListAll retrieves all contacts matching filter, following pagination.
+// The filter limit is used as page size and MaxPages caps the number of
+// pages read. On error the contacts collected so far are returned too.
+func (s *ContactsService) ListAll(ctx context.Context, filter *ContactsFilter) ([]Contact, error) {
+	f := ContactsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return readAll(ctx, f.Page, f.MaxPages, s.pages(&f))
+}
+
+// pages returns a page fetcher for contacts matching f
+func (s *ContactsService) pages(f *ContactsFilter) func(ctx context.Context, page int) ([]Contact, bool, error) {
+	return func(ctx context.Context, page int) ([]Contact, bool, error) {
+		f.Page = page
+		resp, err := s.ListWithResponse(ctx, f)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Embedded.Contacts, resp.Links.HasNext(), nil
+	}
+}
+
+// Iterator returns an iterator over all leads matching filter. The filter
+// limit is used as page size.
+func (s *LeadsService) Iterator(ctx context.Context, filter *LeadsFilter) *Iterator[Lead] {
+	f := LeadsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return newIterator(ctx, f.Page, s.pages(&f))
+}
+
+// ListAll retrieves all leads matching filter, following pagination. The
+// filter limit is used as page size and MaxPages caps the number of pages
+// read. On error the leads collected so far are returned too.
+func (s *LeadsService) ListAll(ctx context.Context, filter *LeadsFilter) ([]Lead, error) {
+	f := LeadsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return readAll(ctx, f.Page, f.MaxPages, s.pages(&f))
+}
+
+// pages returns a page fetcher for leads matching f
+func (s *LeadsService) pages(f *LeadsFilter) func(ctx context.Context, page int) ([]Lead, bool, error) {
+	return func(ctx context.Context, page int) ([]Lead, bool, error) {
+		f.Page = page
+		resp, err := s.ListWithResponse(ctx, f)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Embedded.Leads, resp.Links.HasNext(), nil
+	}
+}
+
+// Iterator returns an iterator over all companies matching filter. The
+// filter limit is used as page size.
+func (s *CompaniesService) Iterator(ctx context.Context, filter *CompaniesFilter) *Iterator[Company] {
+	f := CompaniesFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return newIterator(ctx, f.Page, s.pages(&f))
+}
+
+// ListAll retrieves all companies matching filter, following pagination.
+// The filter limit is used as page size and MaxPages caps the number of
+// pages read. On error the companies collected so far are returned too.
+func (s *CompaniesService) ListAll(ctx context.Context, filter *CompaniesFilter) ([]Company, error) {
+	f := CompaniesFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return readAll(ctx, f.Page, f.MaxPages, s.pages(&f))
+}
+
+// pages returns a page fetcher for companies matching f
+func (s *CompaniesService) pages(f *CompaniesFilter) func(ctx context.Context, page int) ([]Company, bool, error) {
+	return func(ctx context.Context, page int) ([]Company, bool, error) {
+		f.Page = page
+		resp, err := s.ListWithResponse(ctx, f)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Embedded.Companies, resp.Links.HasNext(), nil
+	}
+}
@@ -0,0 +1,134 @@
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestContactsService_Iterator(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected limit=2, got '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch page := r.URL.Query().Get("page"); page {
+		case "1":
+			w.Write([]byte(`{"_embedded": {"contacts": [{"id": 1}, {"id": 2}]}, "_links": {"next": {"href": "page2"}}}`))
+		case "2":
+			w.Write([]byte(`{"_embedded": {"contacts": [{"id": 3}]}}`))
+		default:
+			t.Errorf("Unexpected page '%s'", page)
+		}
+	})
+
+	it := client.Contacts.Iterator(context.Background(), &ContactsFilter{Limit: 2})
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("Expected contacts [1 2 3], got %v", ids)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestLeadsService_IteratorError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	it := client.Leads.Iterator(context.Background(), nil)
+	if it.Next() {
+		t.Error("Expected no items")
+	}
+	if it.Err() == nil {
+		t.Error("Expected API error")
+	}
+}
+
+func TestCompaniesService_IteratorEmpty(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	it := client.Companies.Iterator(context.Background(), nil)
+	if it.Next() || it.Err() != nil {
+		t.Errorf("Expected empty iteration without error, got %v", it.Err())
+	}
+}
+
+func TestLeadsService_ListAll(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch page := r.URL.Query().Get("page"); page {
+		case "1":
+			w.Write([]byte(`{"_embedded": {"leads": [{"id": 1}, {"id": 2}]}, "_links": {"next": {"href": "page2"}}}`))
+		case "2":
+			w.Write([]byte(`{"_embedded": {"leads": [{"id": 3}]}}`))
+		default:
+			t.Errorf("Unexpected page '%s'", page)
+		}
+	})
+
+	leads, err := client.Leads.ListAll(context.Background(), &LeadsFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(leads) != 3 || leads[2].ID != 3 {
+		t.Errorf("Expected leads [1 2 3], got %v", leads)
+	}
+}
+
+func TestContactsService_ListAllMaxPages(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"contacts": [{"id": 1}]}, "_links": {"next": {"href": "next"}}}`))
+	})
+
+	contacts, err := client.Contacts.ListAll(context.Background(), &ContactsFilter{MaxPages: 3})
+	if !errors.Is(err, ErrTooManyPages) {
+		t.Fatalf("Expected ErrTooManyPages, got %v", err)
+	}
+	if len(contacts) != 3 || requests != 3 {
+		t.Errorf("Expected 3 contacts from 3 requests, got %d from %d", len(contacts), requests)
+	}
+}
+
+func TestCompaniesService_ListAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"companies": [{"id": 1}]}, "_links": {"next": {"href": "next"}}}`))
+	})
+
+	companies, err := client.Companies.ListAll(ctx, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if len(companies) != 1 {
+		t.Errorf("Expected the first page to be returned, got %v", companies)
+	}
+}
@@ -2,19 +2,31 @@ package amocrm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+)
+
+// Reserved lead statuses shared by all pipelines
+const (
+	// StatusWon is the "closed - won" status
+	StatusWon = 142
+
+	// StatusLost is the "closed - lost" status
+	StatusLost = 143
 )
 
 // Lead represents an AmoCRM lead (deal)
 type Lead struct {
 	ID                 int                `json:"id,omitempty"`
 	Name               string             `json:"name"`
-	Price              int                `json:"price,omitempty"`
+	Price              int                `json:"price,omitempty"` // zero is omitted, so a price can't be reset to 0
 	ResponsibleUserID  int                `json:"responsible_user_id,omitempty"`
 	GroupID            int                `json:"group_id,omitempty"`
 	StatusID           int                `json:"status_id,omitempty"`
 	PipelineID         int                `json:"pipeline_id,omitempty"`
 	LossReasonID       int                `json:"loss_reason_id,omitempty"`
+	SourceID           int                `json:"source_id,omitempty"`
 	CreatedBy          int                `json:"created_by,omitempty"`
 	UpdatedBy          int                `json:"updated_by,omitempty"`
 	CreatedAt          int64              `json:"created_at,omitempty"`
@@ -22,12 +34,118 @@ type Lead struct {
 	ClosedAt           int64              `json:"closed_at,omitempty"`
 	ClosestTaskAt      int64              `json:"closest_task_at,omitempty"`
 	IsClosed           bool               `json:"is_closed,omitempty"`
+	IsDeleted          bool               `json:"is_deleted,omitempty"`
 	CustomFieldsValues []CustomFieldValue `json:"custom_fields_values,omitempty"`
-	Score              int                `json:"score,omitempty"`
+	Score              *int               `json:"score,omitempty"` // nil when the lead has no score
 	AccountID          int                `json:"account_id,omitempty"`
 	LaborCost          int                `json:"labor_cost,omitempty"`
 	Links              *Links             `json:"_links,omitempty"`
 	Embedded           *Embedded          `json:"_embedded,omitempty"`
+
+	// IsPriceModifiedByRobot is returned with with=is_price_modified_by_robot
+	IsPriceModifiedByRobot bool `json:"is_price_modified_by_robot,omitempty"`
+}
+
+// CustomFieldValue returns the first value of a custom field of the lead
+// as a string
+func (l *Lead) CustomFieldValue(fieldID int) (string, bool) {
+	return customFields(l.CustomFieldsValues).first(fieldID)
+}
+
+// CustomFieldValues returns all values of a custom field of the lead
+// as strings, e.g. every phone number
+func (l *Lead) CustomFieldValues(fieldID int) []string {
+	return customFields(l.CustomFieldsValues).all(fieldID)
+}
+
+// FieldByCode returns a custom field of the lead by its code (e.g.
+// PHONE), or nil if the lead has no value for it
+func (l *Lead) FieldByCode(code string) *CustomFieldValue {
+	return customFields(l.CustomFieldsValues).byCode(code)
+}
+
+// IsWon reports whether the lead is in the "closed - won" status
+func (l *Lead) IsWon() bool {
+	return l.StatusID == StatusWon
+}
+
+// IsLost reports whether the lead is in the "closed - lost" status
+func (l *Lead) IsLost() bool {
+	return l.StatusID == StatusLost
+}
+
+// ContactIDs returns the IDs of the contacts linked to the lead. Contacts
+// are embedded only when the lead is requested with with=contacts.
+func (l *Lead) ContactIDs() []int {
+	if l.Embedded == nil {
+		return nil
+	}
+
+	ids := make([]int, len(l.Embedded.Contacts))
+	for i, contact := range l.Embedded.Contacts {
+		ids[i] = contact.ID
+	}
+	return ids
+}
+
+// MainContactID returns the ID of the main contact of the lead, if any
+func (l *Lead) MainContactID() (int, bool) {
+	if l.Embedded == nil {
+		return 0, false
+	}
+
+	for _, contact := range l.Embedded.Contacts {
+		if contact.IsMain {
+			return contact.ID, true
+		}
+	}
+	return 0, false
+}
+
+// MainContact returns the embedded main contact of the lead, or nil. The
+// embedded contact carries only its ID and links; fetch it by ID for the
+// full contact.
+func (l *Lead) MainContact() *Contact {
+	if l.Embedded == nil {
+		return nil
+	}
+
+	for i := range l.Embedded.Contacts {
+		if l.Embedded.Contacts[i].IsMain {
+			return &l.Embedded.Contacts[i]
+		}
+	}
+	return nil
+}
+
+// Company returns the embedded company of the lead, or nil
+func (l *Lead) Company() *Company {
+	if l.Embedded == nil || len(l.Embedded.Companies) == 0 {
+		return nil
+	}
+	return &l.Embedded.Companies[0]
+}
+
+// CompanyIDs returns the IDs of the companies linked to the lead. The API
+// embeds companies as a list even though a lead has at most one company.
+func (l *Lead) CompanyIDs() []int {
+	if l.Embedded == nil {
+		return nil
+	}
+
+	ids := make([]int, len(l.Embedded.Companies))
+	for i, company := range l.Embedded.Companies {
+		ids[i] = company.ID
+	}
+	return ids
+}
+
+// CompanyID returns the ID of the company linked to the lead, or 0
+func (l *Lead) CompanyID() int {
+	if ids := l.CompanyIDs(); len(ids) > 0 {
+		return ids[0]
+	}
+	return 0
 }
 
 // LeadsService handles communication with lead-related methods
@@ -42,27 +160,71 @@ type LeadsResponse struct {
 	} `json:"_embedded"`
 	Links Links `json:"_links"`
 	Page  Page  `json:"_page,omitempty"`
+
+	// Header holds the HTTP response headers, e.g. X-RateLimit-Remaining
+	Header http.Header `json:"-"`
 }
 
 // LeadsFilter represents filter options for listing leads
 type LeadsFilter struct {
-	Query      string
-	Limit      int
-	Page       int
-	With       string // comma-separated list: contacts, catalog_elements, loss_reason
-	Order      string // created_at, updated_at, id, closed_at
-	StatusID   []int
-	PipelineID int
+	Query          string
+	Limit          int
+	Page           int
+	With           string       // comma-separated list: contacts, catalog_elements, loss_reason, is_price_modified_by_robot, source_id
+	Order          string       // created_at, updated_at, id, closed_at
+	OrderDirection string       // OrderAsc or OrderDesc for Order; asc when empty
+	OrderBy        []OrderField // multiple sort keys, applied after Order
+	MaxPages       int          // page cap of ListAll; DefaultListAllMaxPages when 0
+	StatusID       []int
+	PipelineID     int
+
+	// Time range filters (keys: from, to)
+	CreatedAt     map[string]int64
+	UpdatedAt     map[string]int64
+	ClosestTaskAt map[string]int64
+
+	// Price range filter (keys: from, to)
+	Price map[string]int64
+
+	// IDs limits the list to the given IDs. List splits more than 250 IDs
+	// into several requests and merges the results, returning every
+	// matching entity: Limit and Page are ignored then. ListWithResponse
+	// sends them in one request, which AmoCRM rejects above 250.
+	IDs []int
+
+	// ResponsibleUserID limits the list to entities of the given users
+	ResponsibleUserID []int
 }
 
 // List retrieves a list of leads
 func (s *LeadsService) List(ctx context.Context, filter *LeadsFilter) ([]Lead, error) {
+	if filter != nil && len(filter.IDs) > maxIDsPerRequest {
+		return listByIDChunks(filter.IDs, func(chunk []int) ([]Lead, error) {
+			f := *filter
+			f.IDs = chunk
+			f.Limit = len(chunk)
+			f.Page = 1
+			return s.List(ctx, &f)
+		})
+	}
+
+	resp, err := s.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Leads, nil
+}
+
+// ListWithResponse retrieves a list of leads with pagination links and the
+// response headers
+func (s *LeadsService) ListWithResponse(ctx context.Context, filter *LeadsFilter) (*LeadsResponse, error) {
 	path := "/leads"
 
 	if filter != nil {
 		path += "?"
 		if filter.Query != "" {
-			path += fmt.Sprintf("query=%s&", filter.Query)
+			path += searchQuery(filter.Query)
 		}
 		if filter.Limit > 0 {
 			path += fmt.Sprintf("limit=%d&", filter.Limit)
@@ -74,22 +236,31 @@ func (s *LeadsService) List(ctx context.Context, filter *LeadsFilter) ([]Lead, e
 			path += fmt.Sprintf("with=%s&", filter.With)
 		}
 		if filter.Order != "" {
-			path += fmt.Sprintf("order[%s]=asc&", filter.Order)
+			path += orderFilter([]OrderField{{Field: filter.Order, Direction: filter.OrderDirection}})
 		}
+		path += orderFilter(filter.OrderBy)
 		if filter.PipelineID > 0 {
 			path += fmt.Sprintf("filter[pipeline_id]=%d&", filter.PipelineID)
 		}
 		for _, statusID := range filter.StatusID {
 			path += fmt.Sprintf("filter[statuses][0][status_id]=%d&", statusID)
 		}
+		path += rangeFilter("created_at", filter.CreatedAt)
+		path += rangeFilter("updated_at", filter.UpdatedAt)
+		path += rangeFilter("closest_task_at", filter.ClosestTaskAt)
+		path += rangeFilter("price", filter.Price)
+		path += listFilter("id", filter.IDs)
+		path += listFilter("responsible_user_id", filter.ResponsibleUserID)
 	}
 
 	var resp LeadsResponse
-	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+	header, err := s.client.getJSONHeader(ctx, path, &resp)
+	if err != nil {
 		return nil, err
 	}
+	resp.Header = header
 
-	return resp.Embedded.Leads, nil
+	return &resp, nil
 }
 
 // GetByID retrieves a lead by ID
@@ -104,6 +275,26 @@ func (s *LeadsService) GetByID(ctx context.Context, id int) (*Lead, error) {
 	return &lead, nil
 }
 
+// GetByIDs retrieves leads by their IDs, embedding the related entities
+// listed in with (e.g. "contacts"). IDs are requested in chunks of
+// maxIDsPerRequest, one request per chunk.
+func (s *LeadsService) GetByIDs(ctx context.Context, ids []int, with string) ([]Lead, error) {
+	return listByIDChunks(ids, func(chunk []int) ([]Lead, error) {
+		path := fmt.Sprintf("/leads?limit=%d&", len(chunk))
+		path += listFilter("id", chunk)
+		if with != "" {
+			path += fmt.Sprintf("with=%s&", with)
+		}
+
+		var resp LeadsResponse
+		if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+			return nil, err
+		}
+
+		return resp.Embedded.Leads, nil
+	})
+}
+
 // Create creates a new lead
 func (s *LeadsService) Create(ctx context.Context, lead *Lead) (*Lead, error) {
 	type request struct {
@@ -203,49 +394,210 @@ func (s *LeadsService) UpdateBatch(ctx context.Context, leads []*Lead) ([]Lead,
 
 // LinkContacts links contacts to a lead
 func (s *LeadsService) LinkContacts(ctx context.Context, leadID int, contactIDs []int) error {
-	type linkRequest struct {
-		ToEntityID   int    `json:"to_entity_id"`
-		ToEntityType string `json:"to_entity_type"`
+	links := make([]EntityLink, len(contactIDs))
+	for i, contactID := range contactIDs {
+		links[i] = EntityLink{
+			ToEntityID:   contactID,
+			ToEntityType: EntityTypeContact,
+		}
 	}
 
-	type request struct {
-		Links []linkRequest `json:"links"`
+	return s.client.link(ctx, EntityTypeLead, leadID, links)
+}
+
+// AddContact creates a contact and links it to a lead. If linking fails,
+// the new contact is deleted again; the returned error then also reports
+// whether that cleanup failed.
+func (s *LeadsService) AddContact(ctx context.Context, leadID int, contact *Contact) (*Contact, error) {
+	if leadID == 0 {
+		return nil, fmt.Errorf("lead ID is required to add a contact")
+	}
+
+	created, err := s.client.Contacts.Create(ctx, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.LinkContacts(ctx, leadID, []int{created.ID}); err != nil {
+		if delErr := s.client.Contacts.Delete(ctx, created.ID); delErr != nil {
+			return nil, fmt.Errorf("failed to link contact %d to lead %d: %w (removing the contact also failed: %v)", created.ID, leadID, err, delErr)
+		}
+		return nil, fmt.Errorf("failed to link contact %d to lead %d: %w", created.ID, leadID, err)
 	}
 
-	links := make([]linkRequest, len(contactIDs))
+	return created, nil
+}
+
+// LinkCompany links a company to a lead
+func (s *LeadsService) LinkCompany(ctx context.Context, leadID int, companyID int) error {
+	links := []EntityLink{
+		{
+			ToEntityID:   companyID,
+			ToEntityType: EntityTypeCompany,
+		},
+	}
+
+	return s.client.link(ctx, EntityTypeLead, leadID, links)
+}
+
+// UnlinkContacts removes links between a lead and contacts
+func (s *LeadsService) UnlinkContacts(ctx context.Context, leadID int, contactIDs []int) error {
+	links := make([]EntityLink, len(contactIDs))
 	for i, contactID := range contactIDs {
-		links[i] = linkRequest{
+		links[i] = EntityLink{
 			ToEntityID:   contactID,
-			ToEntityType: "contacts",
+			ToEntityType: EntityTypeContact,
 		}
 	}
 
-	req := request{Links: links}
+	return s.client.unlink(ctx, EntityTypeLead, leadID, links)
+}
+
+// UnlinkCompany removes the link between a lead and a company
+func (s *LeadsService) UnlinkCompany(ctx context.Context, leadID int, companyID int) error {
+	links := []EntityLink{
+		{
+			ToEntityID:   companyID,
+			ToEntityType: EntityTypeCompany,
+		},
+	}
 
-	path := fmt.Sprintf("/leads/%d/link", leadID)
-	return s.client.PostJSON(ctx, path, req, nil)
+	return s.client.unlink(ctx, EntityTypeLead, leadID, links)
 }
 
-// LinkCompany links a company to a lead
-func (s *LeadsService) LinkCompany(ctx context.Context, leadID int, companyID int) error {
-	type linkRequest struct {
-		ToEntityID   int    `json:"to_entity_id"`
-		ToEntityType string `json:"to_entity_type"`
+// LinkCatalogElement links a catalog element (e.g. a product) to a lead
+// with the given quantity
+func (s *LeadsService) LinkCatalogElement(ctx context.Context, leadID, catalogID, elementID, quantity int) error {
+	if catalogID == 0 {
+		return fmt.Errorf("catalog ID is required to link a catalog element")
+	}
+
+	links := []EntityLink{
+		{
+			ToEntityID:   elementID,
+			ToEntityType: EntityTypeCatalogElement,
+			Metadata: &LinkMetadata{
+				CatalogID: catalogID,
+				Quantity:  quantity,
+			},
+		},
+	}
+
+	return s.client.link(ctx, EntityTypeLead, leadID, links)
+}
+
+// Win moves a lead to the "closed - won" status
+func (s *LeadsService) Win(ctx context.Context, id int) (*Lead, error) {
+	return s.setStatus(ctx, id, StatusWon, 0)
+}
+
+// Lose moves a lead to the "closed - lost" status and sets its loss reason
+// in the same update
+func (s *LeadsService) Lose(ctx context.Context, id int, lossReasonID int) (*Lead, error) {
+	if lossReasonID == 0 {
+		return nil, fmt.Errorf("loss reason ID is required")
+	}
+
+	return s.setStatus(ctx, id, StatusLost, lossReasonID)
+}
+
+// setStatus updates only the status (and loss reason) of a lead
+func (s *LeadsService) setStatus(ctx context.Context, id int, statusID int, lossReasonID int) (*Lead, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("lead ID is required for update")
 	}
 
 	type request struct {
-		Links []linkRequest `json:"links"`
+		ID           int `json:"id"`
+		StatusID     int `json:"status_id"`
+		LossReasonID int `json:"loss_reason_id,omitempty"`
 	}
 
 	req := request{
-		Links: []linkRequest{
-			{
-				ToEntityID:   companyID,
-				ToEntityType: "companies",
-			},
-		},
+		ID:           id,
+		StatusID:     statusID,
+		LossReasonID: lossReasonID,
 	}
 
-	path := fmt.Sprintf("/leads/%d/link", leadID)
-	return s.client.PostJSON(ctx, path, req, nil)
+	var lead Lead
+	path := fmt.Sprintf("/leads/%d", id)
+	if err := s.client.PatchJSON(ctx, path, req, &lead); err != nil {
+		return nil, err
+	}
+
+	return &lead, nil
+}
+
+// SetTags replaces the tags of a lead with the named tags, creating tags
+// that do not exist yet
+func (s *LeadsService) SetTags(ctx context.Context, leadID int, tagNames []string) error {
+	if leadID == 0 {
+		return fmt.Errorf("lead ID is required for update")
+	}
+
+	tags, err := s.client.Tags.EnsureTags(ctx, EntityTypeLead, tagNames)
+	if err != nil {
+		return err
+	}
+
+	type request struct {
+		ID       int          `json:"id"`
+		Embedded EmbeddedTags `json:"_embedded"`
+	}
+
+	req := request{
+		ID:       leadID,
+		Embedded: EmbeddedTags{Tags: tags},
+	}
+
+	path := fmt.Sprintf("/leads/%d", leadID)
+	return s.client.PatchJSON(ctx, path, req, nil)
+}
+
+// Delete deletes a lead by ID
+func (s *LeadsService) Delete(ctx context.Context, id int) error {
+	if id == 0 {
+		return fmt.Errorf("lead ID is required for delete")
+	}
+
+	path := fmt.Sprintf("/leads/%d", id)
+	return s.client.DeleteJSON(ctx, path)
+}
+
+// DeleteBatch deletes multiple leads, sending at most maxIDsPerRequest IDs
+// per request. It returns the number of deleted leads; errors of failed
+// chunks are joined, and a chunk deleted only in part reports its failed
+// IDs with a *PartialDeleteError.
+func (s *LeadsService) DeleteBatch(ctx context.Context, ids []int) (int, error) {
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("no lead IDs to delete")
+	}
+	for i, id := range ids {
+		if id == 0 {
+			return 0, fmt.Errorf("lead ID is required for delete at index %d", i)
+		}
+	}
+
+	var deleted int
+	var errs []error
+	for start := 0; start < len(ids); start += maxIDsPerRequest {
+		end := start + maxIDsPerRequest
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		n, err := s.client.deleteBatch(ctx, EntityTypeLead, ids[start:end])
+		deleted += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete leads %d-%d: %w", start, end-1, err))
+		}
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+// GetLinks retrieves the entities a lead is linked to. The filter is
+// optional and may narrow the result to one entity type.
+func (s *LeadsService) GetLinks(ctx context.Context, leadID int, filter *LinksFilter) ([]EntityLink, error) {
+	return s.client.getLinks(ctx, EntityTypeLead, leadID, filter)
 }
@@ -0,0 +1,437 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLeadIsWonIsLost(t *testing.T) {
+	won := &Lead{StatusID: StatusWon}
+	if !won.IsWon() || won.IsLost() {
+		t.Error("Lead with status 142 should be won and not lost")
+	}
+
+	lost := &Lead{StatusID: StatusLost}
+	if !lost.IsLost() || lost.IsWon() {
+		t.Error("Lead with status 143 should be lost and not won")
+	}
+}
+
+func TestLeadsService_Lose(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected method PATCH, got '%s'", r.Method)
+		}
+		if r.URL.Path != "/api/v4/leads/10" {
+			t.Errorf("Expected path '/api/v4/leads/10', got '%s'", r.URL.Path)
+		}
+
+		var body map[string]int
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if body["status_id"] != StatusLost || body["loss_reason_id"] != 5 {
+			t.Errorf("Unexpected body: %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 10, "status_id": 143, "loss_reason_id": 5}`))
+	})
+
+	lead, err := client.Leads.Lose(context.Background(), 10, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !lead.IsLost() {
+		t.Errorf("Expected lead to be lost, got status %d", lead.StatusID)
+	}
+}
+
+func TestLeadsService_LoseRequiresReason(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("No request expected without a loss reason")
+	})
+
+	if _, err := client.Leads.Lose(context.Background(), 10, 0); err == nil {
+		t.Error("Expected error for zero loss reason ID")
+	}
+}
+
+func TestLeadsService_ListClosestTaskAt(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("filter[closest_task_at][from]") != "100" || q.Get("filter[closest_task_at][to]") != "200" {
+			t.Errorf("Unexpected query '%s'", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Leads.List(context.Background(), &LeadsFilter{
+		ClosestTaskAt: map[string]int64{"from": 100, "to": 200},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestLeadDecodeFixture(t *testing.T) {
+	fixture := `{
+		"id": 19619,
+		"name": "Сделка для примера",
+		"price": 46333,
+		"responsible_user_id": 123321,
+		"group_id": 625,
+		"status_id": 142,
+		"pipeline_id": 1300,
+		"loss_reason_id": null,
+		"source_id": 7,
+		"created_by": 321123,
+		"updated_by": 321123,
+		"created_at": 1453279607,
+		"updated_at": 1502193501,
+		"closed_at": 1483005931,
+		"closest_task_at": null,
+		"is_deleted": false,
+		"is_price_modified_by_robot": true,
+		"custom_fields_values": null,
+		"score": null,
+		"account_id": 5135160,
+		"labor_cost": null,
+		"_links": {"self": {"href": "https://example.amocrm.ru/api/v4/leads/19619"}},
+		"_embedded": {"tags": [], "companies": []}
+	}`
+
+	var lead Lead
+	if err := json.Unmarshal([]byte(fixture), &lead); err != nil {
+		t.Fatalf("Failed to decode lead: %v", err)
+	}
+
+	if lead.Score != nil {
+		t.Errorf("Expected nil score, got %d", *lead.Score)
+	}
+	if !lead.IsPriceModifiedByRobot || lead.IsDeleted || lead.SourceID != 7 {
+		t.Errorf("Unexpected flags: %+v", lead)
+	}
+
+	score := 0
+	data, _ := json.Marshal(Lead{Name: "Scored", Score: &score})
+	if !strings.Contains(string(data), `"score":0`) {
+		t.Errorf("Expected explicit zero score in %s", data)
+	}
+}
+
+func TestLeadsService_GetByIDsWith(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"leads": [{"id": 1}]}}`))
+	})
+
+	ids := make([]int, 251)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	leads, err := client.Leads.GetByIDs(context.Background(), ids, "contacts")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(queries))
+	}
+	if len(leads) != 2 {
+		t.Errorf("Expected leads from both chunks, got %d", len(leads))
+	}
+
+	expected := "limit=1&filter[id][]=251&with=contacts&"
+	if queries[1] != expected {
+		t.Errorf("Expected query '%s', got '%s'", expected, queries[1])
+	}
+	if !strings.Contains(queries[0], "limit=250&filter[id][]=1&") || !strings.HasSuffix(queries[0], "filter[id][]=250&with=contacts&") {
+		t.Errorf("Unexpected first chunk query '%s'", queries[0])
+	}
+}
+
+func TestLeadsService_Delete(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/leads/5" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Leads.Delete(context.Background(), 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.Leads.Delete(context.Background(), 0); err == nil {
+		t.Error("Expected error for missing lead ID")
+	}
+}
+
+func TestLeadsService_DeleteBatch(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/leads" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body []struct {
+			ID int `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) > 250 {
+			t.Errorf("Expected at most 250 IDs per request, got %d", len(body))
+		}
+
+		if requests == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"title": "Bad Request"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ids := make([]int, 300)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	deleted, err := client.Leads.DeleteBatch(context.Background(), ids)
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+	if deleted != 250 {
+		t.Errorf("Expected 250 deleted leads, got %d", deleted)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected the second chunk's API error, got %v", err)
+	}
+}
+
+func TestLeadsService_ListOrderBy(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expected := "order[updated_at]=desc&order[id]=asc&"
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Leads.List(context.Background(), &LeadsFilter{
+		OrderBy: []OrderField{
+			{Field: "updated_at", Direction: OrderDesc},
+			{Field: "id"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestLeadsService_ListPrice(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expected := "filter[price][from]=100000&filter[price][to]=500000&"
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Leads.List(context.Background(), &LeadsFilter{
+		Price: map[string]int64{"from": 100000, "to": 500000},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestLeadsService_AddContact(t *testing.T) {
+	var deleted bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/contacts":
+			w.Write([]byte(`{"_embedded": {"contacts": [{"id": 30, "name": "Ivan"}]}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/leads/10/link":
+			w.Write([]byte(`{"_embedded": {"links": []}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/leads/11/link":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v4/contacts/30":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	contact, err := client.Leads.AddContact(ctx, 10, &Contact{Name: "Ivan"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if contact.ID != 30 {
+		t.Errorf("Expected contact ID 30, got %d", contact.ID)
+	}
+
+	_, err = client.Leads.AddContact(ctx, 11, &Contact{Name: "Ivan"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected link error, got %v", err)
+	}
+	if !deleted {
+		t.Error("Expected created contact to be removed after link failure")
+	}
+}
+
+func TestLeadEmbeddedContactsAndCompanies(t *testing.T) {
+	fixture := `{
+		"id": 1,
+		"name": "Deal",
+		"_embedded": {
+			"tags": [],
+			"contacts": [
+				{"id": 20, "is_main": false, "_links": {"self": {"href": "https://example.amocrm.ru/api/v4/contacts/20"}}},
+				{"id": 21, "is_main": true, "_links": {"self": {"href": "https://example.amocrm.ru/api/v4/contacts/21"}}}
+			],
+			"companies": [{"id": 30, "_links": {"self": {"href": "https://example.amocrm.ru/api/v4/companies/30"}}}]
+		}
+	}`
+
+	var lead Lead
+	if err := json.Unmarshal([]byte(fixture), &lead); err != nil {
+		t.Fatalf("Failed to decode lead: %v", err)
+	}
+
+	if ids := lead.ContactIDs(); len(ids) != 2 || ids[0] != 20 || ids[1] != 21 {
+		t.Errorf("Unexpected contact IDs: %v", ids)
+	}
+	if id, ok := lead.MainContactID(); !ok || id != 21 {
+		t.Errorf("Expected main contact 21, got %d (%v)", id, ok)
+	}
+	if id := lead.CompanyID(); id != 30 {
+		t.Errorf("Expected company 30, got %d", id)
+	}
+
+	var empty Lead
+	if empty.ContactIDs() != nil || empty.CompanyID() != 0 {
+		t.Error("Expected no linked entities without embedded data")
+	}
+}
+
+func TestLeadsService_ListWithResponseEmbedded(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("with") != "contacts" {
+			t.Errorf("Expected with=contacts, got '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/hal+json")
+		w.Write([]byte(`{
+			"_page": 1,
+			"_links": {"self": {"href": "https://example.amocrm.ru/api/v4/leads?with=contacts&page=1&limit=2"}},
+			"_embedded": {
+				"leads": [
+					{
+						"id": 100,
+						"name": "Card A",
+						"status_id": 40,
+						"_embedded": {
+							"tags": [],
+							"companies": [{"id": 300, "_links": {"self": {"href": "https://example.amocrm.ru/api/v4/companies/300"}}}],
+							"contacts": [
+								{"id": 200, "is_main": true, "_links": {"self": {"href": "https://example.amocrm.ru/api/v4/contacts/200"}}},
+								{"id": 201, "is_main": false, "_links": {"self": {"href": "https://example.amocrm.ru/api/v4/contacts/201"}}}
+							]
+						}
+					},
+					{
+						"id": 101,
+						"name": "Card B",
+						"status_id": 40,
+						"_embedded": {"tags": [], "companies": [], "contacts": []}
+					}
+				]
+			}
+		}`))
+	})
+
+	resp, err := client.Leads.ListWithResponse(context.Background(), &LeadsFilter{With: "contacts", Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	leads := resp.Embedded.Leads
+	if len(leads) != 2 {
+		t.Fatalf("Expected 2 leads, got %d", len(leads))
+	}
+
+	if main := leads[0].MainContact(); main == nil || main.ID != 200 {
+		t.Errorf("Expected main contact 200, got %+v", main)
+	}
+	if company := leads[0].Company(); company == nil || company.ID != 300 {
+		t.Errorf("Expected company 300, got %+v", company)
+	}
+	if ids := leads[0].ContactIDs(); len(ids) != 2 {
+		t.Errorf("Expected 2 contact IDs, got %v", ids)
+	}
+
+	if leads[1].MainContact() != nil || leads[1].Company() != nil {
+		t.Error("Expected no embedded entities on the second lead")
+	}
+	if resp.Page.Number != 1 {
+		t.Errorf("Expected page 1, got %d", resp.Page.Number)
+	}
+}
+
+func TestLeadsService_LinkCatalogElement(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/leads/10/link" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"links":[{"to_entity_id":55,"to_entity_type":"catalog_elements","metadata":{"catalog_id":7,"quantity":3}}]}`
+		if string(body) != expected {
+			t.Errorf("Expected body %s, got %s", expected, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"links": []}}`))
+	})
+
+	if err := client.Leads.LinkCatalogElement(context.Background(), 10, 7, 55, 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestLeadsService_UnlinkContacts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/leads/10/unlink" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"links":[{"to_entity_id":1,"to_entity_type":"contacts"},{"to_entity_id":2,"to_entity_type":"contacts"}]}`
+		if string(body) != expected {
+			t.Errorf("Expected body %s, got %s", expected, body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Leads.UnlinkContacts(context.Background(), 10, []int{1, 2}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+)
+
+// EntityLink represents a link from an entity to another entity
+type EntityLink struct {
+	ToEntityID   int           `json:"to_entity_id"`
+	ToEntityType EntityType    `json:"to_entity_type"`
+	Metadata     *LinkMetadata `json:"metadata,omitempty"`
+}
+
+// LinkMetadata represents additional link data
+type LinkMetadata struct {
+	CatalogID   int  `json:"catalog_id,omitempty"`   // catalog elements only
+	Quantity    int  `json:"quantity,omitempty"`     // catalog elements only
+	PriceID     int  `json:"price_id,omitempty"`     // catalog elements only
+	MainContact bool `json:"main_contact,omitempty"` // contacts only
+	UpdatedBy   int  `json:"updated_by,omitempty"`
+}
+
+// LinksFilter represents filter parameters for entity links
+type LinksFilter struct {
+	ToEntityType EntityType // only links to this entity type
+	ToEntityID   int        // only links to this entity, requires ToEntityType
+}
+
+// LinksResponse represents the response for entity links
+type LinksResponse struct {
+	Embedded struct {
+		Links []EntityLink `json:"links"`
+	} `json:"_embedded"`
+}
+
+// LinksService handles linking entities to each other
+type LinksService struct {
+	client *Client
+}
+
+// LinkMany links an entity to several entities in one request. The links
+// may target different entity types, e.g. a contact and a company.
+func (s *LinksService) LinkMany(ctx context.Context, entityType EntityType, entityID int, links []EntityLink) error {
+	if len(links) == 0 {
+		return fmt.Errorf("at least one link is required")
+	}
+
+	for i, link := range links {
+		if link.ToEntityID == 0 || link.ToEntityType == "" {
+			return fmt.Errorf("link target is required at index %d", i)
+		}
+	}
+
+	return s.client.link(ctx, entityType, entityID, links)
+}
+
+// getLinks retrieves the links of an entity
+func (c *Client) getLinks(ctx context.Context, entityType EntityType, entityID int, filter *LinksFilter) ([]EntityLink, error) {
+	if entityID == 0 {
+		return nil, fmt.Errorf("entity ID is required to get links")
+	}
+
+	path := fmt.Sprintf("/%s/%d/links", entityType, entityID)
+
+	if filter != nil {
+		path += "?"
+		if filter.ToEntityType != "" {
+			path += fmt.Sprintf("filter[to_entity_type]=%s&", filter.ToEntityType)
+		}
+		if filter.ToEntityID > 0 {
+			path += fmt.Sprintf("filter[to_entity_id]=%d&", filter.ToEntityID)
+		}
+	}
+
+	var resp LinksResponse
+	if err := c.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Links, nil
+}
+
+// link posts links for an entity
+func (c *Client) link(ctx context.Context, entityType EntityType, entityID int, links []EntityLink) error {
+	return c.postLinks(ctx, "link", entityType, entityID, links)
+}
+
+// unlink removes links of an entity
+func (c *Client) unlink(ctx context.Context, entityType EntityType, entityID int, links []EntityLink) error {
+	return c.postLinks(ctx, "unlink", entityType, entityID, links)
+}
+
+// postLinks posts a link request to the link or unlink endpoint of an entity
+func (c *Client) postLinks(ctx context.Context, action string, entityType EntityType, entityID int, links []EntityLink) error {
+	type request struct {
+		Links []EntityLink `json:"links"`
+	}
+
+	req := request{Links: links}
+
+	path := fmt.Sprintf("/%s/%d/%s", entityType, entityID, action)
+	return c.PostJSON(ctx, path, req, nil)
+}
@@ -0,0 +1,88 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestLinksService_LinkMany(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/leads/10/link" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			Links []EntityLink `json:"links"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+
+		if len(body.Links) != 2 ||
+			body.Links[0].ToEntityType != EntityTypeContact ||
+			body.Links[1].ToEntityType != EntityTypeCompany {
+			t.Errorf("Unexpected links: %+v", body.Links)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	})
+
+	err := client.Links.LinkMany(context.Background(), EntityTypeLead, 10, []EntityLink{
+		{ToEntityID: 1, ToEntityType: EntityTypeContact, Metadata: &LinkMetadata{MainContact: true}},
+		{ToEntityID: 2, ToEntityType: EntityTypeCompany},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.Links.LinkMany(context.Background(), EntityTypeLead, 10, nil); err == nil {
+		t.Error("Expected error for empty links")
+	}
+}
+
+func TestLeadsService_GetLinks(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v4/leads/10/links" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if r.URL.Query().Get("filter[to_entity_type]") != "catalog_elements" {
+			t.Errorf("Expected to_entity_type filter, got '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"links": [
+			{"to_entity_id": 55, "to_entity_type": "catalog_elements", "metadata": {"catalog_id": 7, "quantity": 2}}
+		]}}`))
+	})
+
+	links, err := client.Leads.GetLinks(context.Background(), 10, &LinksFilter{ToEntityType: EntityTypeCatalogElement})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(links) != 1 || links[0].ToEntityID != 55 || links[0].Metadata == nil || links[0].Metadata.Quantity != 2 {
+		t.Errorf("Unexpected links: %+v", links)
+	}
+}
+
+func TestContactsService_GetLinksEmpty(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/contacts/3/links" || r.URL.RawQuery != "" {
+			t.Errorf("Unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	links, err := client.Contacts.GetLinks(context.Background(), 3, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(links) != 0 {
+		t.Errorf("Expected no links, got %d", len(links))
+	}
+}
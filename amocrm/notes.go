@@ -3,6 +3,8 @@ package amocrm
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"time"
 )
 
 // NoteType represents note type constants
@@ -15,8 +17,12 @@ const (
 	NoteTypeSMSIn          NoteType = "sms_in"
 	NoteTypeSMSOut         NoteType = "sms_out"
 	NoteTypeServiceMessage NoteType = "service_message"
+	NoteTypeAttachment     NoteType = "attachment"
 )
 
+// fileUUIDPattern matches UUIDs of files uploaded to the AmoCRM file storage
+var fileUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // Note represents an AmoCRM note
 type Note struct {
 	ID                int                    `json:"id,omitempty"`
@@ -51,14 +57,25 @@ type NotesFilter struct {
 	Limit      int
 	Page       int
 	NoteType   []NoteType
-	EntityID   int
+	EntityID   int // ListByType only: notes of this entity
 	EntityType EntityType
+	UpdatedAt  map[string]int64 // from, to
 }
 
 // List retrieves a list of notes for an entity
 func (s *NotesService) List(ctx context.Context, entityType EntityType, entityID int, filter *NotesFilter) ([]Note, error) {
 	path := fmt.Sprintf("/%s/%d/notes", entityType, entityID)
+	return s.list(ctx, path, filter, false)
+}
+
+// ListByType retrieves notes of all entities of a type across the account,
+// e.g. every call note for a nightly sync
+func (s *NotesService) ListByType(ctx context.Context, entityType EntityType, filter *NotesFilter) ([]Note, error) {
+	path := fmt.Sprintf("/%s/notes", entityType)
+	return s.list(ctx, path, filter, true)
+}
 
+func (s *NotesService) list(ctx context.Context, path string, filter *NotesFilter, byEntity bool) ([]Note, error) {
 	if filter != nil {
 		path += "?"
 		if filter.Limit > 0 {
@@ -70,6 +87,10 @@ func (s *NotesService) List(ctx context.Context, entityType EntityType, entityID
 		for _, noteType := range filter.NoteType {
 			path += fmt.Sprintf("filter[note_type][]=%s&", noteType)
 		}
+		if byEntity && filter.EntityID > 0 {
+			path += fmt.Sprintf("filter[entity_id][]=%d&", filter.EntityID)
+		}
+		path += rangeFilter("updated_at", filter.UpdatedAt)
 	}
 
 	var resp NotesResponse
@@ -140,3 +161,62 @@ func (s *NotesService) CreateBatch(ctx context.Context, entityType EntityType, e
 
 	return resp.Embedded.Notes, nil
 }
+
+// CreateAttachment creates a note attaching an uploaded file to an entity
+func (s *NotesService) CreateAttachment(ctx context.Context, entityType EntityType, entityID int, fileUUID, fileName string) (*Note, error) {
+	if !fileUUIDPattern.MatchString(fileUUID) {
+		return nil, fmt.Errorf("invalid file UUID: %q", fileUUID)
+	}
+
+	if fileName == "" {
+		return nil, fmt.Errorf("file name is required")
+	}
+
+	note := &Note{
+		EntityID: entityID,
+		NoteType: NoteTypeAttachment,
+		Params: map[string]interface{}{
+			"file_uuid": fileUUID,
+			"file_name": fileName,
+		},
+	}
+
+	return s.Create(ctx, entityType, note)
+}
+
+// CallParams describes a call for a call_in or call_out note
+type CallParams struct {
+	UID        string        // unique call ID in the telephony system
+	Duration   time.Duration // sent in whole seconds
+	Source     string        // telephony integration name
+	Link       string        // call recording URL
+	Phone      string
+	CallResult string
+	CallStatus int
+}
+
+// NewCallNote builds a call note for an entity. noteType is NoteTypeCallIn
+// or NoteTypeCallOut.
+func NewCallNote(entityID int, noteType NoteType, call CallParams) *Note {
+	params := map[string]interface{}{
+		"uid":      call.UID,
+		"duration": Seconds(call.Duration),
+		"source":   call.Source,
+		"phone":    call.Phone,
+	}
+	if call.Link != "" {
+		params["link"] = call.Link
+	}
+	if call.CallResult != "" {
+		params["call_result"] = call.CallResult
+	}
+	if call.CallStatus != 0 {
+		params["call_status"] = call.CallStatus
+	}
+
+	return &Note{
+		EntityID: entityID,
+		NoteType: noteType,
+		Params:   params,
+	}
+}
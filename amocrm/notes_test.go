@@ -0,0 +1,90 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNotesService_CreateAttachment(t *testing.T) {
+	const fileUUID = "6f2a8c1e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/leads/10/notes" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+
+		var body struct {
+			Notes []Note `json:"notes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+
+		note := body.Notes[0]
+		if note.NoteType != NoteTypeAttachment || note.Params["file_uuid"] != fileUUID || note.Params["file_name"] != "offer.pdf" {
+			t.Errorf("Unexpected note: %+v", note)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"notes": [{"id": 1, "entity_id": 10, "note_type": "attachment"}]}}`))
+	})
+
+	ctx := context.Background()
+	if _, err := client.Notes.CreateAttachment(ctx, EntityTypeLead, 10, fileUUID, "offer.pdf"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.Notes.CreateAttachment(ctx, EntityTypeLead, 10, "not-a-uuid", "offer.pdf"); err == nil {
+		t.Error("Expected error for invalid file UUID")
+	}
+}
+
+func TestNewCallNote(t *testing.T) {
+	note := NewCallNote(10, NoteTypeCallIn, CallParams{
+		UID:      "call-1",
+		Duration: 2*time.Minute + 30*time.Second,
+		Source:   "Телефония",
+		Phone:    "+79001234567",
+	})
+
+	data, err := json.Marshal(note)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"entity_id":10,"note_type":"call_in","params":{"duration":150,"phone":"+79001234567","source":"Телефония","uid":"call-1"}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestNotesService_ListByType(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/leads/notes" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+
+		expected := "filter[note_type][]=call_in&filter[note_type][]=call_out&filter[updated_at][from]=1000&filter[updated_at][to]=2000&"
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"notes": [{"id": 1, "entity_id": 10, "note_type": "call_in"}, {"id": 2, "entity_id": 11, "note_type": "call_out"}]}}`))
+	})
+
+	notes, err := client.Notes.ListByType(context.Background(), EntityTypeLead, &NotesFilter{
+		NoteType:  []NoteType{NoteTypeCallIn, NoteTypeCallOut},
+		UpdatedAt: map[string]int64{"from": 1000, "to": 2000},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(notes) != 2 || notes[1].EntityID != 11 {
+		t.Errorf("Unexpected notes: %+v", notes)
+	}
+}
@@ -0,0 +1,129 @@
+package amocrm
+
+import "context"
+
+// PageChecker reports whether a page of a list has any items. Checkers of
+// endpoints that report _page_count also return that count as total, and
+// 0 otherwise.
+type PageChecker func(ctx context.Context, page int) (ok bool, total int, err error)
+
+// PaginationService helps to size paginated lists
+type PaginationService struct {
+	client *Client
+}
+
+// FindTotalPages returns the number of non-empty pages of a list. Most list
+// endpoints do not report a page count, so pages are probed: doubling the
+// page number until an empty page is found, then binary searching between
+// the last non-empty and the first empty page. A list of N pages costs
+// about 2*log2(N) requests. When the first page reports a page count, it
+// is returned right away.
+func (s *PaginationService) FindTotalPages(ctx context.Context, check PageChecker) (int, error) {
+	ok, total, err := check(ctx, 1)
+	if err != nil || !ok {
+		return 0, err
+	}
+	if total > 0 {
+		return total, nil
+	}
+
+	// last is known to be non-empty, empty is known to be empty
+	last, empty := 1, 2
+	for {
+		ok, _, err := check(ctx, empty)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		last, empty = empty, empty*2
+	}
+
+	for empty-last > 1 {
+		mid := (last + empty) / 2
+		ok, _, err := check(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			last = mid
+		} else {
+			empty = mid
+		}
+	}
+
+	return last, nil
+}
+
+// CreateTasksPageChecker returns a PageChecker for the tasks matching
+// filter. The filter limit is the page size.
+func (s *PaginationService) CreateTasksPageChecker(filter *TasksFilter) PageChecker {
+	f := TasksFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return func(ctx context.Context, page int) (bool, int, error) {
+		f.Page = page
+		tasks, err := s.client.Tasks.List(ctx, &f)
+		if err != nil {
+			return false, 0, err
+		}
+		return len(tasks) > 0, 0, nil
+	}
+}
+
+// CreateEventsPageChecker returns a PageChecker for the events matching
+// filter. The filter limit is the page size.
+func (s *PaginationService) CreateEventsPageChecker(filter *EventsFilter) PageChecker {
+	f := EventsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return func(ctx context.Context, page int) (bool, int, error) {
+		f.Page = page
+		resp, err := s.client.Events.ListWithResponse(ctx, &f)
+		if err != nil {
+			return false, 0, err
+		}
+		return len(resp.Embedded.Events) > 0, resp.PageCount, nil
+	}
+}
+
+// CreateUsersPageChecker returns a PageChecker for the users matching
+// filter. The filter limit is the page size.
+func (s *PaginationService) CreateUsersPageChecker(filter *UsersFilter) PageChecker {
+	f := UsersFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return func(ctx context.Context, page int) (bool, int, error) {
+		f.Page = page
+		resp, err := s.client.Users.ListWithResponse(ctx, &f)
+		if err != nil {
+			return false, 0, err
+		}
+		return len(resp.Embedded.Users) > 0, resp.PageCount, nil
+	}
+}
+
+// CreateRolesPageChecker returns a PageChecker for the roles matching
+// filter. The filter limit is the page size.
+func (s *PaginationService) CreateRolesPageChecker(filter *RolesFilter) PageChecker {
+	f := RolesFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	return func(ctx context.Context, page int) (bool, int, error) {
+		f.Page = page
+		resp, err := s.client.Roles.list(ctx, &f)
+		if err != nil {
+			return false, 0, err
+		}
+		return len(resp.Embedded.Roles) > 0, resp.PageCount, nil
+	}
+}
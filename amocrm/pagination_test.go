@@ -0,0 +1,83 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestPaginationService_FindTotalPages(t *testing.T) {
+	for _, total := range []int{0, 1, 2, 5, 8, 13} {
+		var checked []int
+		check := func(ctx context.Context, page int) (bool, int, error) {
+			checked = append(checked, page)
+			return page <= total, 0, nil
+		}
+
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+		got, err := client.Pagination.FindTotalPages(context.Background(), check)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got != total {
+			t.Errorf("Expected %d pages, got %d (checked %v)", total, got, checked)
+		}
+	}
+}
+
+func TestPaginationService_CreateEventsPageChecker(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "100" {
+			t.Errorf("Expected limit 100, got '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page > 3 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"_embedded": {"events": [{"id": "a"}]}}`))
+	})
+
+	check := client.Pagination.CreateEventsPageChecker(&EventsFilter{Limit: 100})
+	total, err := client.Pagination.FindTotalPages(context.Background(), check)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if total != 3 {
+		t.Errorf("Expected 3 pages, got %d", total)
+	}
+}
+
+func TestEventsResponse_TotalPages(t *testing.T) {
+	resp := &EventsResponse{PageCount: 7}
+	if total, ok := resp.TotalPages(); !ok || total != 7 {
+		t.Errorf("Expected 7 pages, got %d (%v)", total, ok)
+	}
+
+	if _, ok := (&EventsResponse{}).TotalPages(); ok {
+		t.Error("Expected no page count")
+	}
+}
+
+func TestPaginationService_FindTotalPagesFromPageCount(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_page_count": 12, "_embedded": {"users": [{"id": 1}]}}`))
+	})
+
+	total, err := client.Pagination.FindTotalPages(context.Background(), client.Pagination.CreateUsersPageChecker(nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if total != 12 || requests != 1 {
+		t.Errorf("Expected 12 pages in 1 request, got %d in %d", total, requests)
+	}
+}
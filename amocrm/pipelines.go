@@ -0,0 +1,325 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Pipeline represents an AmoCRM leads pipeline
+type Pipeline struct {
+	ID           int               `json:"id,omitempty"`
+	Name         string            `json:"name"`
+	Sort         int               `json:"sort,omitempty"`
+	IsMain       *bool             `json:"is_main,omitempty"`
+	IsUnsortedOn *bool             `json:"is_unsorted_on,omitempty"`
+	IsArchive    *bool             `json:"is_archive,omitempty"`
+	AccountID    int               `json:"account_id,omitempty"`
+	Links        *Links            `json:"_links,omitempty"`
+	Embedded     *PipelineEmbedded `json:"_embedded,omitempty"`
+}
+
+// PipelineEmbedded represents embedded pipeline data
+type PipelineEmbedded struct {
+	Statuses []Status `json:"statuses,omitempty"`
+}
+
+// Status represents a pipeline status (stage)
+type Status struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name"`
+	Sort       int    `json:"sort,omitempty"`
+	IsEditable bool   `json:"is_editable,omitempty"` // set by AmoCRM
+	PipelineID int    `json:"pipeline_id,omitempty"`
+	Color      string `json:"color,omitempty"`
+	Type       int    `json:"type,omitempty"` // 0 - regular, 1 - unsorted
+	AccountID  int    `json:"account_id,omitempty"`
+}
+
+// PipelinesService handles communication with pipeline-related methods
+type PipelinesService struct {
+	client *Client
+
+	countsMu sync.Mutex
+	counts   map[[2]int]statusCount
+}
+
+// PipelinesResponse represents the API response for pipelines list
+type PipelinesResponse struct {
+	Embedded struct {
+		Pipelines []Pipeline `json:"pipelines"`
+	} `json:"_embedded"`
+	Links Links `json:"_links"`
+}
+
+// List retrieves all pipelines with their statuses
+func (s *PipelinesService) List(ctx context.Context) ([]Pipeline, error) {
+	var resp PipelinesResponse
+	if err := s.client.GetJSON(ctx, "/leads/pipelines", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Pipelines, nil
+}
+
+// GetByID retrieves a pipeline with its statuses
+func (s *PipelinesService) GetByID(ctx context.Context, id int) (*Pipeline, error) {
+	path := fmt.Sprintf("/leads/pipelines/%d", id)
+
+	var pipeline Pipeline
+	if err := s.client.GetJSON(ctx, path, &pipeline); err != nil {
+		return nil, err
+	}
+
+	return &pipeline, nil
+}
+
+// Create creates a pipeline. Its statuses are sent nested under
+// _embedded.statuses; the system statuses (unsorted, won, lost) are added
+// by AmoCRM.
+func (s *PipelinesService) Create(ctx context.Context, pipeline *Pipeline) (*Pipeline, error) {
+	if pipeline.Name == "" {
+		return nil, fmt.Errorf("pipeline name is required")
+	}
+
+	type request struct {
+		Pipelines []Pipeline `json:"pipelines"`
+	}
+
+	req := request{
+		Pipelines: []Pipeline{*pipeline},
+	}
+
+	var resp PipelinesResponse
+	if err := s.client.PostJSON(ctx, "/leads/pipelines", req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Embedded.Pipelines) == 0 {
+		return nil, fmt.Errorf("no pipeline returned from API")
+	}
+
+	return &resp.Embedded.Pipelines[0], nil
+}
+
+// Update updates the name, sort and flags of a pipeline. Flags left nil are
+// not sent and keep their current value. Statuses are managed with
+// CreateStatus and DeleteStatus.
+func (s *PipelinesService) Update(ctx context.Context, pipeline *Pipeline) (*Pipeline, error) {
+	if pipeline.ID == 0 {
+		return nil, fmt.Errorf("pipeline ID is required for update")
+	}
+
+	type request struct {
+		Name         string `json:"name,omitempty"`
+		Sort         int    `json:"sort,omitempty"`
+		IsMain       *bool  `json:"is_main,omitempty"`
+		IsUnsortedOn *bool  `json:"is_unsorted_on,omitempty"`
+		IsArchive    *bool  `json:"is_archive,omitempty"`
+	}
+
+	req := request{
+		Name:         pipeline.Name,
+		Sort:         pipeline.Sort,
+		IsMain:       pipeline.IsMain,
+		IsUnsortedOn: pipeline.IsUnsortedOn,
+		IsArchive:    pipeline.IsArchive,
+	}
+
+	path := fmt.Sprintf("/leads/pipelines/%d", pipeline.ID)
+
+	var updated Pipeline
+	if err := s.client.PatchJSON(ctx, path, req, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// Delete deletes a pipeline
+func (s *PipelinesService) Delete(ctx context.Context, id int) error {
+	if id == 0 {
+		return fmt.Errorf("pipeline ID is required for delete")
+	}
+
+	path := fmt.Sprintf("/leads/pipelines/%d", id)
+	return s.client.DeleteJSON(ctx, path)
+}
+
+// StatusesResponse represents the API response for pipeline statuses
+type StatusesResponse struct {
+	Embedded struct {
+		Statuses []Status `json:"statuses"`
+	} `json:"_embedded"`
+	Links Links `json:"_links"`
+}
+
+// CreateStatus adds a status to a pipeline
+func (s *PipelinesService) CreateStatus(ctx context.Context, pipelineID int, status *Status) (*Status, error) {
+	if pipelineID == 0 {
+		return nil, fmt.Errorf("pipeline ID is required")
+	}
+
+	if status.Name == "" {
+		return nil, fmt.Errorf("status name is required")
+	}
+
+	type request struct {
+		Statuses []Status `json:"statuses"`
+	}
+
+	req := request{
+		Statuses: []Status{*status},
+	}
+
+	path := fmt.Sprintf("/leads/pipelines/%d/statuses", pipelineID)
+
+	var resp StatusesResponse
+	if err := s.client.PostJSON(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Embedded.Statuses) == 0 {
+		return nil, fmt.Errorf("no status returned from API")
+	}
+
+	return &resp.Embedded.Statuses[0], nil
+}
+
+// DeleteStatus deletes a status of a pipeline
+func (s *PipelinesService) DeleteStatus(ctx context.Context, pipelineID, statusID int) error {
+	if pipelineID == 0 || statusID == 0 {
+		return fmt.Errorf("pipeline ID and status ID are required for delete")
+	}
+
+	path := fmt.Sprintf("/leads/pipelines/%d/statuses/%d", pipelineID, statusID)
+	return s.client.DeleteJSON(ctx, path)
+}
+
+const (
+	// statusCountsConcurrency bounds parallel requests of StatusCounts
+	statusCountsConcurrency = 3
+
+	// statusCountsTTL is how long StatusCounts results are cached
+	statusCountsTTL = time.Minute
+
+	// statusCountsPageSize is the maximum page size of the leads list
+	statusCountsPageSize = 250
+)
+
+type statusCount struct {
+	count     int
+	fetchedAt time.Time
+}
+
+// StatusCounts returns the number of leads in each status of a pipeline.
+// The API has no counters, so leads of every status are paged through with
+// the maximum page size: a status with N leads costs ceil(N/250) requests
+// (at least one), at most 3 statuses are counted in parallel and results
+// are cached for a minute. When statusIDs is empty all statuses of the
+// pipeline are counted, which costs one more request.
+func (s *PipelinesService) StatusCounts(ctx context.Context, pipelineID int, statusIDs []int) (map[int]int, error) {
+	if len(statusIDs) == 0 {
+		pipelines, err := s.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pipelines {
+			if p.ID == pipelineID && p.Embedded != nil {
+				for _, status := range p.Embedded.Statuses {
+					statusIDs = append(statusIDs, status.ID)
+				}
+			}
+		}
+		if len(statusIDs) == 0 {
+			return nil, fmt.Errorf("pipeline %d not found or has no statuses", pipelineID)
+		}
+	}
+
+	// Cached counts are collected before any goroutine writes to counts
+	counts := make(map[int]int, len(statusIDs))
+	var uncached []int
+	for _, statusID := range statusIDs {
+		if count, ok := s.cachedCount(pipelineID, statusID); ok {
+			counts[statusID] = count
+			continue
+		}
+		uncached = append(uncached, statusID)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, statusCountsConcurrency)
+
+	for _, statusID := range uncached {
+		wg.Add(1)
+		go func(statusID int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			count, err := s.countStatus(ctx, pipelineID, statusID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			counts[statusID] = count
+		}(statusID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return counts, nil
+}
+
+// countStatus counts the leads of one status by paging through them
+func (s *PipelinesService) countStatus(ctx context.Context, pipelineID, statusID int) (int, error) {
+	count := 0
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/leads?limit=%d&page=%d&filter[statuses][0][pipeline_id]=%d&filter[statuses][0][status_id]=%d",
+			statusCountsPageSize, page, pipelineID, statusID)
+
+		var resp LeadsResponse
+		if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+			return 0, err
+		}
+
+		count += len(resp.Embedded.Leads)
+		if !resp.Links.HasNext() || len(resp.Embedded.Leads) < statusCountsPageSize {
+			break
+		}
+	}
+
+	s.countsMu.Lock()
+	if s.counts == nil {
+		s.counts = make(map[[2]int]statusCount)
+	}
+	s.counts[[2]int{pipelineID, statusID}] = statusCount{count: count, fetchedAt: timeNow()}
+	s.countsMu.Unlock()
+
+	return count, nil
+}
+
+func (s *PipelinesService) cachedCount(pipelineID, statusID int) (int, bool) {
+	s.countsMu.Lock()
+	defer s.countsMu.Unlock()
+
+	cached, ok := s.counts[[2]int{pipelineID, statusID}]
+	if !ok || timeNow().Sub(cached.fetchedAt) > statusCountsTTL {
+		return 0, false
+	}
+
+	return cached.count, true
+}
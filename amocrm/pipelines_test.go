@@ -0,0 +1,175 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPipelinesService_StatusCounts(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/api/v4/leads/pipelines" {
+			w.Write([]byte(`{"_embedded": {"pipelines": [{"id": 1, "name": "Sales", "_embedded": {"statuses": [{"id": 10}, {"id": 20}]}}]}}`))
+			return
+		}
+
+		q := r.URL.Query()
+		if q.Get("filter[statuses][0][pipeline_id]") != "1" {
+			t.Errorf("Expected pipeline filter in '%s'", r.URL.RawQuery)
+		}
+
+		// Status 10 has 251 leads over two pages, status 20 has none
+		switch q.Get("filter[statuses][0][status_id]") + "/" + q.Get("page") {
+		case "10/1":
+			leads := strings.TrimSuffix(strings.Repeat(`{"id": 1},`, 250), ",")
+			fmt.Fprintf(w, `{"_page": 1, "_links": {"next": {"href": "next"}}, "_embedded": {"leads": [%s]}}`, leads)
+		case "10/2":
+			w.Write([]byte(`{"_page": 2, "_embedded": {"leads": [{"id": 2}]}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	counts, err := client.Pipelines.StatusCounts(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if counts[10] != 251 || counts[20] != 0 || len(counts) != 2 {
+		t.Errorf("Unexpected counts: %v", counts)
+	}
+
+	before := atomic.LoadInt32(&requests)
+	if _, err := client.Pipelines.StatusCounts(ctx, 1, []int{10, 20}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&requests) != before {
+		t.Error("Expected cached counts to be reused")
+	}
+}
+
+func TestPipelinesService_StatusCountsPartlyCached(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"leads": [{"id": 1}]}}`))
+	})
+
+	ctx := context.Background()
+	cached := []int{2, 4, 6, 8}
+	if _, err := client.Pipelines.StatusCounts(ctx, 1, cached); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Uncached statuses come first, so their goroutines run while the
+	// cached ones are collected
+	atomic.StoreInt32(&requests, 0)
+	counts, err := client.Pipelines.StatusCounts(ctx, 1, []int{1, 3, 2, 5, 4, 7, 6, 9, 8})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(counts) != 9 {
+		t.Errorf("Expected counts of 9 statuses, got %v", counts)
+	}
+	for id, count := range counts {
+		if count != 1 {
+			t.Errorf("Expected 1 lead in status %d, got %d", id, count)
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 5 {
+		t.Errorf("Expected requests for the 5 uncached statuses only, got %d", got)
+	}
+}
+
+func TestPipelinesService_Create(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/leads/pipelines" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"pipelines":[{"name":"Продажи","_embedded":{"statuses":[{"name":"Первичный контакт","sort":10},{"name":"Переговоры","sort":20}]}}]}`
+		if string(body) != expected {
+			t.Errorf("Expected body %s, got %s", expected, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"pipelines": [{"id": 5, "name": "Продажи", "_embedded": {"statuses": [{"id": 51, "name": "Первичный контакт"}]}}]}}`))
+	})
+
+	pipeline, err := client.Pipelines.Create(context.Background(), &Pipeline{
+		Name: "Продажи",
+		Embedded: &PipelineEmbedded{Statuses: []Status{
+			{Name: "Первичный контакт", Sort: 10},
+			{Name: "Переговоры", Sort: 20},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if pipeline.ID != 5 || pipeline.Embedded.Statuses[0].ID != 51 {
+		t.Errorf("Unexpected pipeline: %+v", pipeline)
+	}
+}
+
+func TestPipelinesService_Update(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v4/leads/pipelines/5" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 5, "name": "Продажи"}`))
+	})
+
+	ctx := context.Background()
+	if _, err := client.Pipelines.Update(ctx, &Pipeline{ID: 5, Name: "Продажи"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Pipelines.Update(ctx, &Pipeline{ID: 5, IsUnsortedOn: Bool(false), IsArchive: Bool(true)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`{"name":"Продажи"}`,
+		`{"is_unsorted_on":false,"is_archive":true}`,
+	}
+	for i, want := range expected {
+		if bodies[i] != want {
+			t.Errorf("Expected body %s, got %s", want, bodies[i])
+		}
+	}
+}
+
+func TestPipelinesService_DeleteStatus(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/leads/pipelines/5/statuses/51" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Pipelines.DeleteStatus(context.Background(), 5, 51); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.Pipelines.DeleteStatus(context.Background(), 5, 0); err == nil {
+		t.Error("Expected error for missing status ID")
+	}
+}
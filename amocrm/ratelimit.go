@@ -0,0 +1,78 @@
+package amocrm
+
+import (
+	"net/http"
+	"strconv"
+
+	"golang.org/x/time/rate"
+)
+
+// Rate limit headers inspected by WithAdaptiveRateLimit
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+)
+
+const (
+	// adaptiveLowQuota is the share of the quota below which the rate
+	// limit is tightened
+	adaptiveLowQuota = 0.2
+
+	// adaptiveMinScale is the smallest share of the configured rate the
+	// limit is tightened to
+	adaptiveMinScale = 0.1
+)
+
+// WithAdaptiveRateLimit makes the client follow the X-RateLimit-Remaining
+// (and X-RateLimit-Limit, when sent) response headers. Once less than 20%
+// of the quota remains, the rate limit is lowered in proportion to what is
+// left, down to a tenth of the configured rate; it returns to the
+// configured rate as soon as the quota recovers. Responses without the
+// headers leave the limit unchanged. A limiter set with WithSharedLimiter
+// is adjusted for every client sharing it.
+func WithAdaptiveRateLimit() ClientOption {
+	return func(c *Client) {
+		c.adaptiveRateLimit = true
+	}
+}
+
+// adaptRateLimit adjusts the rate limiter to the quota reported in header.
+// rate.Limiter is safe for concurrent use, so concurrent responses only
+// race to set the latest limit.
+func (c *Client) adaptRateLimit(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get(headerRateLimitRemaining))
+	if err != nil {
+		return
+	}
+
+	base := c.baseRateLimit
+	if base == rate.Inf || base <= 0 {
+		return
+	}
+
+	quota := float64(base)
+	if limit, err := strconv.Atoi(header.Get(headerRateLimitLimit)); err == nil && limit > 0 {
+		quota = float64(limit)
+	}
+
+	scale := 1.0
+	if share := float64(remaining) / quota; share < adaptiveLowQuota {
+		scale = share / adaptiveLowQuota
+		if scale < adaptiveMinScale {
+			scale = adaptiveMinScale
+		}
+	}
+
+	newLimit := base * rate.Limit(scale)
+	if c.rateLimiter.Limit() == newLimit {
+		return
+	}
+	c.rateLimiter.SetLimit(newLimit)
+
+	if c.debug {
+		c.logger.Debug("Adjusted rate limit",
+			"remaining", remaining,
+			"limit", float64(newLimit),
+		)
+	}
+}
@@ -0,0 +1,122 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestWithAdaptiveRateLimit(t *testing.T) {
+	var remaining atomic.Value
+	remaining.Store("100")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", remaining.Load().(string))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithSubdomain("test"),
+		WithPermanentToken("test-token"),
+		WithBaseURL(server.URL+"/api/v4"),
+		WithRateLimitBurst(1000, 1000),
+		WithAdaptiveRateLimit(),
+	)
+
+	steps := []struct {
+		remaining string
+		want      rate.Limit
+	}{
+		{"100", 1000},
+		{"10", 500}, // half of the low quota share left
+		{"0", 100},  // never below a tenth of the configured rate
+		{"80", 1000},
+	}
+
+	for _, step := range steps {
+		remaining.Store(step.remaining)
+		if _, err := client.Leads.List(context.Background(), nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := client.rateLimiter.Limit(); got != step.want {
+			t.Errorf("Remaining %s: expected limit %v, got %v", step.remaining, step.want, got)
+		}
+	}
+}
+
+func TestWithAdaptiveRateLimitConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithSubdomain("test"),
+		WithPermanentToken("test-token"),
+		WithBaseURL(server.URL+"/api/v4"),
+		WithRateLimitBurst(1000, 1000),
+		WithAdaptiveRateLimit(),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Leads.List(context.Background(), nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := client.rateLimiter.Limit(); got != 100 {
+		t.Errorf("Expected limit 100, got %v", got)
+	}
+}
+
+func TestWithAdaptiveRateLimitSharedLimiter(t *testing.T) {
+	var remaining atomic.Value
+	remaining.Store("0")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", remaining.Load().(string))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	limiter := rate.NewLimiter(rate.Limit(1000), 1000)
+	opts := []ClientOption{
+		WithSubdomain("test"),
+		WithPermanentToken("test-token"),
+		WithBaseURL(server.URL + "/api/v4"),
+		WithSharedLimiter(limiter),
+		WithAdaptiveRateLimit(),
+	}
+
+	first := NewClient(opts...)
+	if _, err := first.Leads.List(context.Background(), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := limiter.Limit(); got != 100 {
+		t.Fatalf("Expected the shared limit to be tightened to 100, got %v", got)
+	}
+
+	// Created while the shared limiter is tightened
+	second := NewClient(opts...)
+	remaining.Store("100")
+	if _, err := second.Leads.List(context.Background(), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := limiter.Limit(); got != 1000 {
+		t.Errorf("Expected the configured limit 1000 to be restored, got %v", got)
+	}
+}
@@ -0,0 +1,116 @@
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// randInt63n returns a random number in [0, n); overridden in tests with a
+// seeded source
+var randInt63n = rand.Int63n
+
+// sleep waits for d or until ctx is done; overridden in tests
+var sleep = sleepContext
+
+// sendWithRetry calls send, retrying 429 and 5xx responses as configured
+// by WithRetry
+func (c *Client) sendWithRetry(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, bool, error) {
+	for attempt := 0; ; attempt++ {
+		resp, unauthorized, err := c.send(ctx, method, path, contentType, body)
+
+		var apiErr *APIError
+		if attempt >= c.maxRetries || !c.retryable(method) || !errors.As(err, &apiErr) || !retryableStatus(apiErr.StatusCode) {
+			return resp, unauthorized, err
+		}
+
+		delay, ok := parseRetryAfter(apiErr.retryAfter)
+		if !ok {
+			delay = c.backoff(attempt)
+		} else if c.retryMaxDelay > 0 && delay > c.retryMaxDelay {
+			// Retrying before Retry-After would only be throttled again
+			return resp, unauthorized, err
+		}
+
+		if c.debug {
+			c.logger.Debug("Retrying API request",
+				"method", method,
+				"path", path,
+				"status", apiErr.StatusCode,
+				"attempt", attempt+1,
+				"delay", delay,
+			)
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, false, err
+		}
+	}
+}
+
+// retryable reports whether requests with method may be retried
+func (c *Client) retryable(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch:
+		return c.retryNonIdempotent
+	}
+	return true
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// backoff returns the delay before retry attempt+1 using full jitter: a
+// random duration in [0, min(max, base*2^attempt)]
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.retryBaseDelay <= 0 {
+		return 0
+	}
+
+	ceiling := c.retryMaxDelay
+	if attempt < 62 {
+		if delay := c.retryBaseDelay << attempt; delay > 0 && (ceiling <= 0 || delay < ceiling) {
+			ceiling = delay
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(randInt63n(int64(ceiling) + 1))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(timeNow()); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
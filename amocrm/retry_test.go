@@ -0,0 +1,170 @@
+package amocrm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Retried"}`))
+	})
+	WithRetry(3, time.Millisecond)(client)
+
+	lead, err := client.Leads.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+	if lead.Name != "Retried" {
+		t.Errorf("Expected lead 'Retried', got '%s'", lead.Name)
+	}
+}
+
+func TestWithRetryExhausted(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	WithRetry(2, time.Millisecond)(client)
+
+	_, err := client.Leads.GetByID(context.Background(), 1)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 API error, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestWithRetrySkipsPost(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	WithRetry(3, time.Millisecond)(client)
+
+	if _, err := client.Leads.Create(context.Background(), &Lead{Name: "New"}); err == nil {
+		t.Fatal("Expected error")
+	}
+	if requests != 1 {
+		t.Errorf("Expected POST not to be retried, got %d requests", requests)
+	}
+
+	requests = 0
+	WithRetryNonIdempotent(true)(client)
+	client.Leads.Create(context.Background(), &Lead{Name: "New"})
+	if requests != 4 {
+		t.Errorf("Expected 4 requests with non-idempotent retries enabled, got %d", requests)
+	}
+}
+
+func TestWithRetryContextCanceled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	WithRetry(3, time.Millisecond)(client)
+	WithRetryBackoff(time.Millisecond, 2*time.Minute)(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Leads.GetByID(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Retry wait should stop when the context is done")
+	}
+}
+
+func TestWithRetryAfterBeyondMaxDelay(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	WithRetry(3, time.Millisecond)(client)
+
+	start := time.Now()
+	_, err := client.Leads.GetByID(context.Background(), 1)
+	if !IsRateLimited(err) {
+		t.Errorf("Expected rate limit error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected no retry past the maximum delay, got %d requests", requests)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Retry-After beyond the maximum delay should not be waited for")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("2"); !ok || d != 2*time.Second {
+		t.Errorf("Expected 2s, got %v (%v)", d, ok)
+	}
+
+	date := timeNow().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 58*time.Second || d > time.Minute {
+		t.Errorf("Expected about 1m, got %v (%v)", d, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("Expected invalid Retry-After to be ignored")
+	}
+}
+
+func TestRetryBackoffFullJitter(t *testing.T) {
+	defer func(orig func(int64) int64) { randInt63n = orig }(randInt63n)
+	defer func(orig func(context.Context, time.Duration) error) { sleep = orig }(sleep)
+
+	var delays []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	WithRetry(4, 0)(client)
+	WithRetryBackoff(100*time.Millisecond, 300*time.Millisecond)(client)
+
+	randInt63n = rand.New(rand.NewSource(1)).Int63n
+	client.Leads.GetByID(context.Background(), 1)
+
+	seeded := rand.New(rand.NewSource(1))
+	ceilings := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	if len(delays) != len(ceilings) {
+		t.Fatalf("Expected %d retries, got %v", len(ceilings), delays)
+	}
+	for i, ceiling := range ceilings {
+		expected := time.Duration(seeded.Int63n(int64(ceiling) + 1))
+		if delays[i] != expected {
+			t.Errorf("Expected delay %d to be %v, got %v", i, expected, delays[i])
+		}
+		if delays[i] > ceiling {
+			t.Errorf("Delay %d exceeds %v: %v", i, ceiling, delays[i])
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+)
+
+// Role represents an AmoCRM user role
+type Role struct {
+	ID       int           `json:"id,omitempty"`
+	Name     string        `json:"name"`
+	Rights   *RoleRights   `json:"rights,omitempty"`
+	Links    *Links        `json:"_links,omitempty"`
+	Embedded *RoleEmbedded `json:"_embedded,omitempty"`
+}
+
+// RoleRights represents access rights granted by a role
+type RoleRights struct {
+	Leads         *EntityRights `json:"leads,omitempty"`
+	Contacts      *EntityRights `json:"contacts,omitempty"`
+	Companies     *EntityRights `json:"companies,omitempty"`
+	Tasks         *EntityRights `json:"tasks,omitempty"`
+	MailAccess    bool          `json:"mail_access,omitempty"`
+	CatalogAccess bool          `json:"catalog_access,omitempty"`
+}
+
+// EntityRights represents per-action rights on an entity.
+// Values: A - all, G - group, M - own, D - denied
+type EntityRights struct {
+	View   string `json:"view,omitempty"`
+	Edit   string `json:"edit,omitempty"`
+	Add    string `json:"add,omitempty"`
+	Delete string `json:"delete,omitempty"`
+	Export string `json:"export,omitempty"`
+}
+
+// RoleEmbedded represents embedded role data
+type RoleEmbedded struct {
+	Users []RoleUser `json:"users,omitempty"`
+}
+
+// RoleUser references a user that has the role
+type RoleUser struct {
+	ID int `json:"id"`
+}
+
+// RolesService handles communication with role-related methods
+type RolesService struct {
+	client *Client
+}
+
+// RolesResponse represents the API response for roles list
+type RolesResponse struct {
+	Embedded struct {
+		Roles []Role `json:"roles"`
+	} `json:"_embedded"`
+	Links     Links `json:"_links"`
+	Page      int   `json:"_page,omitempty"`
+	PageCount int   `json:"_page_count,omitempty"`
+}
+
+// RolesFilter represents filter options for listing roles
+type RolesFilter struct {
+	Limit int
+	Page  int
+	With  string // users
+}
+
+// List retrieves a list of roles
+func (s *RolesService) List(ctx context.Context, filter *RolesFilter) ([]Role, error) {
+	resp, err := s.list(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Roles, nil
+}
+
+func (s *RolesService) list(ctx context.Context, filter *RolesFilter) (*RolesResponse, error) {
+	path := "/roles"
+
+	if filter != nil {
+		path += "?"
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		if filter.With != "" {
+			path += fmt.Sprintf("with=%s&", filter.With)
+		}
+	}
+
+	var resp RolesResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetByID retrieves a role by ID
+func (s *RolesService) GetByID(ctx context.Context, id int) (*Role, error) {
+	path := fmt.Sprintf("/roles/%d?with=users", id)
+
+	var role Role
+	if err := s.client.GetJSON(ctx, path, &role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// Create creates a new role
+func (s *RolesService) Create(ctx context.Context, role *Role) (*Role, error) {
+	roles, err := s.CreateBatch(ctx, []*Role{role})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("no role returned from API")
+	}
+
+	return &roles[0], nil
+}
+
+// CreateBatch creates multiple roles in one request
+func (s *RolesService) CreateBatch(ctx context.Context, roles []*Role) ([]Role, error) {
+	type request struct {
+		Roles []Role `json:"roles"`
+	}
+
+	rolesValues := make([]Role, len(roles))
+	for i, r := range roles {
+		rolesValues[i] = *r
+	}
+
+	req := request{
+		Roles: rolesValues,
+	}
+
+	var resp RolesResponse
+	if err := s.client.PostJSON(ctx, "/roles", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Roles, nil
+}
+
+// Update updates an existing role
+func (s *RolesService) Update(ctx context.Context, role *Role) (*Role, error) {
+	if role.ID == 0 {
+		return nil, fmt.Errorf("role ID is required for update")
+	}
+
+	type request struct {
+		Roles []Role `json:"roles"`
+	}
+
+	req := request{
+		Roles: []Role{*role},
+	}
+
+	var resp RolesResponse
+	if err := s.client.PatchJSON(ctx, "/roles", req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Embedded.Roles) == 0 {
+		return nil, fmt.Errorf("no role returned from API")
+	}
+
+	return &resp.Embedded.Roles[0], nil
+}
+
+// Delete deletes a role
+func (s *RolesService) Delete(ctx context.Context, id int) error {
+	path := fmt.Sprintf("/roles/%d", id)
+	return s.client.DeleteJSON(ctx, path)
+}
+
+// AssignUsers assigns users to a role. A user has exactly one role, so the
+// API models assignment on the role side: the role is updated with the users
+// under _embedded.users, which moves them from whatever role they had.
+func (s *RolesService) AssignUsers(ctx context.Context, roleID int, userIDs []int) error {
+	if roleID == 0 {
+		return fmt.Errorf("role ID is required")
+	}
+
+	if len(userIDs) == 0 {
+		return fmt.Errorf("at least one user ID is required")
+	}
+
+	type roleUpdate struct {
+		ID       int          `json:"id"`
+		Embedded RoleEmbedded `json:"_embedded"`
+	}
+
+	type request struct {
+		Roles []roleUpdate `json:"roles"`
+	}
+
+	users := make([]RoleUser, len(userIDs))
+	for i, userID := range userIDs {
+		if userID == 0 {
+			return fmt.Errorf("user ID is required at index %d", i)
+		}
+		users[i] = RoleUser{ID: userID}
+	}
+
+	req := request{
+		Roles: []roleUpdate{
+			{
+				ID:       roleID,
+				Embedded: RoleEmbedded{Users: users},
+			},
+		},
+	}
+
+	return s.client.PatchJSON(ctx, "/roles", req, nil)
+}
@@ -0,0 +1,73 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRolesService_CreateEnvelope(t *testing.T) {
+	var bodies []map[string][]Role
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/roles" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string][]Role
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Expected a roles envelope, got decode error: %v", err)
+		}
+		bodies = append(bodies, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"roles": [{"id": 1, "name": "Managers"}, {"id": 2, "name": "Admins"}]}}`))
+	})
+
+	ctx := context.Background()
+	if _, err := client.Roles.Create(ctx, &Role{Name: "Managers"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Roles.CreateBatch(ctx, []*Role{{Name: "Managers"}, {Name: "Admins"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(bodies))
+	}
+	if len(bodies[0]["roles"]) != 1 || bodies[0]["roles"][0].Name != "Managers" {
+		t.Errorf("Unexpected Create body: %+v", bodies[0])
+	}
+	if len(bodies[1]["roles"]) != 2 {
+		t.Errorf("Unexpected CreateBatch body: %+v", bodies[1])
+	}
+}
+
+func TestRolesService_AssignUsers(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v4/roles" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string][]Role
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+
+		roles := body["roles"]
+		if len(roles) != 1 || roles[0].ID != 7 || roles[0].Embedded == nil || len(roles[0].Embedded.Users) != 2 {
+			t.Errorf("Unexpected body: %+v", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if err := client.Roles.AssignUsers(context.Background(), 7, []int{10, 11}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.Roles.AssignUsers(context.Background(), 7, nil); err == nil {
+		t.Error("Expected error for empty user list")
+	}
+}
@@ -0,0 +1,55 @@
+package amocrm
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup runs only one call per key at a time; concurrent callers with
+// the same key wait for and share its result
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// do executes fn once for all concurrent callers of key. fn runs on its
+// own goroutine and is not tied to any caller: every caller, including the
+// one that started it, stops waiting when its own ctx is done, while fn
+// keeps running for the others.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run executes fn for call and removes the call once it is done
+func (g *flightGroup) run(key string, call *flightCall, fn func() (interface{}, error)) {
+	call.val, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	close(call.done)
+}
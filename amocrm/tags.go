@@ -0,0 +1,192 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TagsService handles communication with tag-related methods
+type TagsService struct {
+	client *Client
+}
+
+// TagsResponse represents the API response for tags list
+type TagsResponse struct {
+	Embedded struct {
+		Tags []Tag `json:"tags"`
+	} `json:"_embedded"`
+	Links Links `json:"_links"`
+	Page  int   `json:"_page,omitempty"`
+}
+
+// TagsFilter represents filter options for listing tags
+type TagsFilter struct {
+	Limit int
+	Page  int
+	Query string // tag name search
+}
+
+// List retrieves tags of an entity type
+func (s *TagsService) List(ctx context.Context, entityType EntityType, filter *TagsFilter) ([]Tag, error) {
+	resp, err := s.list(ctx, entityType, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Tags, nil
+}
+
+func (s *TagsService) list(ctx context.Context, entityType EntityType, filter *TagsFilter) (*TagsResponse, error) {
+	path := fmt.Sprintf("/%s/tags", entityType)
+
+	if filter != nil {
+		path += "?"
+		if filter.Query != "" {
+			path += searchQuery(filter.Query)
+		}
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+	}
+
+	var resp TagsResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetByID retrieves a tag of an entity type by ID. The API has no single
+// tag endpoint, so the list is filtered by ID; a missing tag yields an
+// error matching ErrNotFound.
+func (s *TagsService) GetByID(ctx context.Context, entityType EntityType, id int) (*Tag, error) {
+	path := fmt.Sprintf("/%s/tags?filter[id]=%d", entityType, id)
+
+	var resp TagsResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, tag := range resp.Embedded.Tags {
+		if tag.ID == id {
+			return &tag, nil
+		}
+	}
+
+	return nil, fmt.Errorf("tag %d: %w", id, ErrNotFound)
+}
+
+// Create creates a tag of an entity type
+func (s *TagsService) Create(ctx context.Context, entityType EntityType, tag *Tag) (*Tag, error) {
+	if tag.Name == "" {
+		return nil, fmt.Errorf("tag name is required")
+	}
+
+	tags, err := s.createBatch(ctx, entityType, []Tag{*tag})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("no tag returned from API")
+	}
+
+	return &tags[0], nil
+}
+
+// DeleteByID deletes a single tag of an entity type
+func (s *TagsService) DeleteByID(ctx context.Context, entityType EntityType, id int) error {
+	return s.Delete(ctx, entityType, []int{id})
+}
+
+// Delete deletes tags of an entity type. If only some of the tags were
+// deleted, a *PartialDeleteError lists the failed IDs.
+func (s *TagsService) Delete(ctx context.Context, entityType EntityType, tagIDs []int) error {
+	if len(tagIDs) == 0 {
+		return fmt.Errorf("no tag IDs to delete")
+	}
+	for i, id := range tagIDs {
+		if id == 0 {
+			return fmt.Errorf("tag ID is required for delete at index %d", i)
+		}
+	}
+
+	path := fmt.Sprintf("/%s/tags", entityType)
+	_, err := s.client.deleteBatchAt(ctx, path, "tags", tagIDs)
+	return err
+}
+
+// EnsureTags resolves tag names to tags of an entity type, creating the
+// ones that do not exist yet. Names are matched case-insensitively and
+// the result follows the order of names.
+func (s *TagsService) EnsureTags(ctx context.Context, entityType EntityType, names []string) ([]Tag, error) {
+	existing := make(map[string]Tag)
+	filter := &TagsFilter{Limit: 250, Page: 1}
+	for {
+		resp, err := s.list(ctx, entityType, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range resp.Embedded.Tags {
+			existing[strings.ToLower(tag.Name)] = tag
+		}
+		if !resp.Links.HasNext() || len(resp.Embedded.Tags) == 0 {
+			break
+		}
+		filter.Page++
+	}
+
+	var missing []Tag
+	seen := make(map[string]bool)
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if _, ok := existing[key]; !ok && !seen[key] {
+			missing = append(missing, Tag{Name: name})
+			seen[key] = true
+		}
+	}
+
+	if len(missing) > 0 {
+		created, err := s.createBatch(ctx, entityType, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range created {
+			existing[strings.ToLower(tag.Name)] = tag
+		}
+	}
+
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tag, ok := existing[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("tag %q was not created", name)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// createBatch creates tags of an entity type
+func (s *TagsService) createBatch(ctx context.Context, entityType EntityType, tags []Tag) ([]Tag, error) {
+	type request struct {
+		Tags []Tag `json:"tags"`
+	}
+
+	req := request{Tags: tags}
+
+	var resp TagsResponse
+	path := fmt.Sprintf("/%s/tags", entityType)
+	if err := s.client.PostJSON(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Tags, nil
+}
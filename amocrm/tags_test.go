@@ -0,0 +1,134 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestLeadsService_SetTags(t *testing.T) {
+	var patched struct {
+		Embedded EmbeddedTags `json:"_embedded"`
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/leads/tags":
+			w.Write([]byte(`{"_embedded": {"tags": [{"id": 1, "name": "VIP"}]}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/leads/tags":
+			var body struct {
+				Tags []Tag `json:"tags"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body.Tags) != 1 || body.Tags[0].Name != "Promo" {
+				t.Errorf("Expected only 'Promo' to be created, got %+v", body.Tags)
+			}
+			w.Write([]byte(`{"_embedded": {"tags": [{"id": 2, "name": "Promo"}]}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v4/leads/10":
+			json.NewDecoder(r.Body).Decode(&patched)
+			w.Write([]byte(`{"id": 10}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if err := client.Leads.SetTags(context.Background(), 10, []string{"vip", "Promo"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tags := patched.Embedded.Tags
+	if len(tags) != 2 || tags[0].ID != 1 || tags[1].ID != 2 {
+		t.Errorf("Unexpected lead tags: %+v", tags)
+	}
+}
+
+func TestTagsService_Delete(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/leads/tags" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"tags": [{"id": 1}]}}`))
+	})
+
+	err := client.Tags.Delete(context.Background(), EntityTypeLead, []int{1, 2})
+
+	var partial *PartialDeleteError
+	if !errors.As(err, &partial) {
+		t.Fatalf("Expected PartialDeleteError, got %v", err)
+	}
+
+	if len(partial.FailedIDs) != 1 || partial.FailedIDs[0] != 2 {
+		t.Errorf("Expected failed IDs [2], got %v", partial.FailedIDs)
+	}
+}
+
+func TestTagsService_Create(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/contacts/tags" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			Tags []Tag `json:"tags"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Tags) != 1 || body.Tags[0].Name != "Import" {
+			t.Errorf("Unexpected body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"tags": [{"id": 5, "name": "Import"}]}}`))
+	})
+
+	tag, err := client.Tags.Create(context.Background(), EntityTypeContact, &Tag{Name: "Import"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if tag.ID != 5 {
+		t.Errorf("Expected tag ID 5, got %d", tag.ID)
+	}
+}
+
+func TestTagsService_GetByID(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("filter[id]") == "5" {
+			w.Write([]byte(`{"_embedded": {"tags": [{"id": 5, "name": "Import"}]}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	tag, err := client.Tags.GetByID(ctx, EntityTypeLead, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tag.Name != "Import" {
+		t.Errorf("Expected tag 'Import', got '%s'", tag.Name)
+	}
+
+	if _, err := client.Tags.GetByID(ctx, EntityTypeLead, 6); !IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestTagsService_ListQuery(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("query"); got != "VIP client" {
+			t.Errorf("Expected query 'VIP client', got '%s'", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Tags.List(context.Background(), EntityTypeLead, &TagsFilter{Query: "VIP client"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package amocrm
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // TaskType represents task type constants
@@ -25,7 +26,7 @@ type Task struct {
 	GroupID           int         `json:"group_id,omitempty"`
 	EntityID          int         `json:"entity_id,omitempty"`
 	EntityType        string      `json:"entity_type,omitempty"` // leads, contacts, companies, customers
-	IsCompleted       bool        `json:"is_completed,omitempty"`
+	IsCompleted       *bool       `json:"is_completed,omitempty"`
 	TaskTypeID        int         `json:"task_type_id,omitempty"`
 	Text              string      `json:"text"`
 	Duration          int         `json:"duration,omitempty"`
@@ -34,6 +35,16 @@ type Task struct {
 	AccountID         int         `json:"account_id,omitempty"`
 }
 
+// SetDuration sets the task duration; the API stores it in seconds
+func (t *Task) SetDuration(d time.Duration) {
+	t.Duration = Seconds(d)
+}
+
+// DurationTime returns the task duration as a time.Duration
+func (t *Task) DurationTime() time.Duration {
+	return time.Duration(t.Duration) * time.Second
+}
+
 // TaskResult represents task completion result
 type TaskResult struct {
 	Text string `json:"text,omitempty"`
@@ -57,10 +68,16 @@ type TasksResponse struct {
 type TasksFilter struct {
 	Limit             int
 	Page              int
-	Filter            map[string]interface{}
-	Order             string
+	Filter            map[string]interface{} // extra filter[key] parameters; slices render as filter[key][]
+	Order             string                 // created_at, complete_till, id
+	OrderDirection    string                 // OrderAsc or OrderDesc for Order; asc when empty
 	ResponsibleUserID int
 	IsCompleted       *bool
+	EntityType        EntityType
+	EntityID          int
+	TaskTypeID        []int
+	CompleteTill      map[string]int64 // from, to
+	UpdatedAt         map[string]int64 // from, to
 }
 
 // List retrieves a list of tasks
@@ -85,6 +102,21 @@ func (s *TasksService) List(ctx context.Context, filter *TasksFilter) ([]Task, e
 			}
 			path += fmt.Sprintf("filter[is_completed]=%d&", completed)
 		}
+		if filter.EntityType != "" {
+			path += fmt.Sprintf("filter[entity_type]=%s&", filter.EntityType)
+		}
+		if filter.EntityID > 0 {
+			path += fmt.Sprintf("filter[entity_id]=%d&", filter.EntityID)
+		}
+		for _, taskType := range filter.TaskTypeID {
+			path += fmt.Sprintf("filter[task_type][]=%d&", taskType)
+		}
+		path += rangeFilter("complete_till", filter.CompleteTill)
+		path += rangeFilter("updated_at", filter.UpdatedAt)
+		path += mapFilter(filter.Filter)
+		if filter.Order != "" {
+			path += orderFilter([]OrderField{{Field: filter.Order, Direction: filter.OrderDirection}})
+		}
 	}
 
 	var resp TasksResponse
@@ -178,11 +210,35 @@ func (s *TasksService) Update(ctx context.Context, task *Task) (*Task, error) {
 	return &resp.Embedded.Tasks[0], nil
 }
 
-// Complete marks a task as completed
+// DueAt sets the task deadline to the given wall clock in the account
+// timezone
+func (s *TasksService) DueAt(ctx context.Context, task *Task, year int, month time.Month, day, hour, min int) error {
+	due, err := s.client.Account.Date(ctx, year, month, day, hour, min)
+	if err != nil {
+		return err
+	}
+	task.CompleteTill = due.Unix()
+	return nil
+}
+
+// Complete marks a task as completed. When resultText is empty, the
+// account settings are checked first (see AccountService.TaskResultRequired)
+// and an error is returned if the account requires a result for completed
+// tasks.
 func (s *TasksService) Complete(ctx context.Context, taskID int, resultText string) error {
+	if resultText == "" {
+		required, err := s.client.Account.TaskResultRequired(ctx)
+		if err != nil {
+			return err
+		}
+		if required {
+			return fmt.Errorf("result text is required to complete task %d on this account", taskID)
+		}
+	}
+
 	task := &Task{
 		ID:          taskID,
-		IsCompleted: true,
+		IsCompleted: Bool(true),
 		Result: &TaskResult{
 			Text: resultText,
 		},
@@ -191,3 +247,24 @@ func (s *TasksService) Complete(ctx context.Context, taskID int, resultText stri
 	_, err := s.Update(ctx, task)
 	return err
 }
+
+// Reopen marks a completed task as not completed
+func (s *TasksService) Reopen(ctx context.Context, taskID int) error {
+	task := &Task{
+		ID:          taskID,
+		IsCompleted: Bool(false),
+	}
+
+	_, err := s.Update(ctx, task)
+	return err
+}
+
+// Delete deletes a task
+func (s *TasksService) Delete(ctx context.Context, id int) error {
+	if id == 0 {
+		return fmt.Errorf("task ID is required for delete")
+	}
+
+	path := fmt.Sprintf("/tasks/%d", id)
+	return s.client.DeleteJSON(ctx, path)
+}
@@ -0,0 +1,176 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTasksService_CompleteRequiresResult(t *testing.T) {
+	var patched bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v4/account":
+			w.Write([]byte(`{"id": 1, "is_task_result_required": true}`))
+		case "/api/v4/tasks":
+			patched = true
+			w.Write([]byte(`{"_embedded": {"tasks": [{"id": 5, "is_completed": true}]}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	err := client.Tasks.Complete(context.Background(), 5, "")
+	if err == nil || !strings.Contains(err.Error(), "result text is required") {
+		t.Fatalf("Expected result requirement error, got %v", err)
+	}
+	if patched {
+		t.Error("Task should not be updated without a required result")
+	}
+
+	if err := client.Tasks.Complete(context.Background(), 5, "Called back"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !patched {
+		t.Error("Expected task to be updated")
+	}
+}
+
+func TestTasksService_CompleteCachesResultRequirement(t *testing.T) {
+	var accountRequests, patches int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v4/account":
+			accountRequests++
+			w.Write([]byte(`{"id": 1, "subdomain": "other", "is_task_result_required": false}`))
+		case "/api/v4/tasks":
+			patches++
+			w.Write([]byte(`{"_embedded": {"tasks": [{"id": 5, "is_completed": true}]}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := client.Tasks.Complete(context.Background(), 5, ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if accountRequests != 1 {
+		t.Errorf("Expected the account to be fetched once, got %d", accountRequests)
+	}
+	if patches != 2 {
+		t.Errorf("Expected 2 task updates, got %d", patches)
+	}
+}
+
+func TestTaskIsCompletedMarshal(t *testing.T) {
+	data, _ := json.Marshal(Task{ID: 1, IsCompleted: Bool(false)})
+	if !strings.Contains(string(data), `"is_completed":false`) {
+		t.Errorf("Expected explicit is_completed false in %s", data)
+	}
+
+	data, _ = json.Marshal(Task{ID: 1})
+	if strings.Contains(string(data), "is_completed") {
+		t.Errorf("Expected is_completed to be omitted when unset in %s", data)
+	}
+}
+
+func TestTasksService_Reopen(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v4/tasks" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"is_completed":false`) {
+			t.Errorf("Expected is_completed false in body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"tasks": [{"id": 5}]}}`))
+	})
+
+	if err := client.Tasks.Reopen(context.Background(), 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestTaskSetDuration(t *testing.T) {
+	task := &Task{}
+	task.SetDuration(90 * time.Minute)
+
+	if task.Duration != 5400 {
+		t.Errorf("Expected duration 5400 seconds, got %d", task.Duration)
+	}
+
+	if task.DurationTime() != 90*time.Minute {
+		t.Errorf("Expected 90m, got %v", task.DurationTime())
+	}
+}
+
+func TestTasksService_ListFilters(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expected := "filter[entity_type]=leads&filter[entity_id]=10&filter[created_by][]=1&filter[created_by][]=2&filter[text]=%D0%BF%D0%BE%D0%B7%D0%B2%D0%BE%D0%BD%D0%B8%D1%82%D1%8C&order[complete_till]=asc&"
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"tasks": [{"id": 1}]}}`))
+	})
+
+	_, err := client.Tasks.List(context.Background(), &TasksFilter{
+		EntityType: EntityTypeLead,
+		EntityID:   10,
+		Filter: map[string]interface{}{
+			"text":       "позвонить",
+			"created_by": []int{1, 2},
+		},
+		Order: "complete_till",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestTasksService_Delete(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/tasks/5" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Tasks.Delete(context.Background(), 5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestTasksService_ListDateRanges(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expected := "filter[task_type][]=1&filter[task_type][]=2&filter[complete_till][from]=1000&filter[complete_till][to]=2000&filter[updated_at][from]=500&"
+		if r.URL.RawQuery != expected {
+			t.Errorf("Expected query '%s', got '%s'", expected, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Tasks.List(context.Background(), &TasksFilter{
+		TaskTypeID:   []int{int(TaskTypeCall), int(TaskTypeMeet)},
+		CompleteTill: map[string]int64{"from": 1000, "to": 2000},
+		UpdatedAt:    map[string]int64{"from": 500},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -1,5 +1,11 @@
 package amocrm
 
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
 // EntityType represents the type of entity
 type EntityType string
 
@@ -8,11 +14,13 @@ const (
 	EntityTypeCompany  EntityType = "companies"
 	EntityTypeLead     EntityType = "leads"
 	EntityTypeCustomer EntityType = "customers"
+
+	EntityTypeCatalogElement EntityType = "catalog_elements"
 )
 
 // CustomFieldValue represents a custom field value
 type CustomFieldValue struct {
-	FieldID   int          `json:"field_id"`
+	FieldID   int          `json:"field_id,omitempty"` // may be omitted when FieldCode is set
 	FieldName string       `json:"field_name,omitempty"`
 	FieldCode string       `json:"field_code,omitempty"`
 	FieldType string       `json:"field_type,omitempty"`
@@ -27,6 +35,113 @@ type FieldValue struct {
 	Enum     string      `json:"enum,omitempty"`
 }
 
+// Strings returns the values of the field as strings. Numbers and booleans
+// decoded from JSON are formatted, and nested objects of enriched fields
+// (e.g. legal entities) are returned as JSON.
+func (v *CustomFieldValue) Strings() []string {
+	values := make([]string, 0, len(v.Values))
+	for _, value := range v.Values {
+		values = append(values, fieldValueString(value.Value))
+	}
+	return values
+}
+
+// fieldValueString formats a decoded custom field value
+func fieldValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
+
+// customFields implements the custom field accessors shared by leads,
+// contacts and companies
+type customFields []CustomFieldValue
+
+// byID finds a field value by field ID
+func (f customFields) byID(fieldID int) *CustomFieldValue {
+	for i := range f {
+		if f[i].FieldID == fieldID {
+			return &f[i]
+		}
+	}
+	return nil
+}
+
+// byCode finds a field value by field code
+func (f customFields) byCode(code string) *CustomFieldValue {
+	for i := range f {
+		if f[i].FieldCode == code {
+			return &f[i]
+		}
+	}
+	return nil
+}
+
+// first returns the first value of a field as a string
+func (f customFields) first(fieldID int) (string, bool) {
+	field := f.byID(fieldID)
+	if field == nil || len(field.Values) == 0 {
+		return "", false
+	}
+	return fieldValueString(field.Values[0].Value), true
+}
+
+// all returns all values of a field as strings
+func (f customFields) all(fieldID int) []string {
+	field := f.byID(fieldID)
+	if field == nil {
+		return nil
+	}
+	return field.Strings()
+}
+
+// NewTextField builds a value of a text, textarea, numeric or url field
+func NewTextField(fieldID int, value string) CustomFieldValue {
+	return CustomFieldValue{
+		FieldID: fieldID,
+		Values:  []FieldValue{{Value: value}},
+	}
+}
+
+// NewMultiField builds a value of a multitext field such as phone or
+// email, where enumCode is the value type (WORK, MOB, PRIV, ...)
+func NewMultiField(fieldID int, enumCode string, value string) CustomFieldValue {
+	return CustomFieldValue{
+		FieldID: fieldID,
+		Values:  []FieldValue{{Value: value, EnumCode: enumCode}},
+	}
+}
+
+// NewDateField builds a value of a date or date_time field. The API
+// expects the date as a Unix timestamp.
+func NewDateField(fieldID int, t time.Time) CustomFieldValue {
+	return CustomFieldValue{
+		FieldID: fieldID,
+		Values:  []FieldValue{{Value: t.Unix()}},
+	}
+}
+
+// NewCheckboxField builds a value of a checkbox field
+func NewCheckboxField(fieldID int, checked bool) CustomFieldValue {
+	return CustomFieldValue{
+		FieldID: fieldID,
+		Values:  []FieldValue{{Value: checked}},
+	}
+}
+
 // EmbeddedTags represents tags in embedded format
 type EmbeddedTags struct {
 	Tags []Tag `json:"tags,omitempty"`
@@ -41,6 +156,12 @@ type Tag struct {
 // Links represents entity links
 type Links struct {
 	Self Link `json:"self,omitempty"`
+	Next Link `json:"next,omitempty"`
+}
+
+// HasNext reports whether the response links to a next page
+func (l Links) HasNext() bool {
+	return l.Next.Href != ""
 }
 
 // Link represents a single link
@@ -56,3 +177,15 @@ type Embedded struct {
 	Leads     []Lead      `json:"leads,omitempty"`
 	Catalog   interface{} `json:"catalog_elements,omitempty"`
 }
+
+// Bool returns a pointer to v, for optional fields where false must be
+// sent explicitly
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Seconds converts d to whole seconds, the unit of duration fields on the
+// wire
+func Seconds(d time.Duration) int {
+	return int(d / time.Second)
+}
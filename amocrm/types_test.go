@@ -0,0 +1,35 @@
+package amocrm
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCustomFieldValueBuilders(t *testing.T) {
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    CustomFieldValue
+		expected string
+	}{
+		{"text", NewTextField(1, "Директор"), `{"field_id":1,"values":[{"value":"Директор"}]}`},
+		{"multi", NewMultiField(2, "WORK", "+79001234567"), `{"field_id":2,"values":[{"value":"+79001234567","enum_code":"WORK"}]}`},
+		{"date", NewDateField(3, date), `{"field_id":3,"values":[{"value":1709294400}]}`},
+		{"checkbox", NewCheckboxField(4, false), `{"field_id":4,"values":[{"value":false}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
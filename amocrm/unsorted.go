@@ -0,0 +1,226 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+)
+
+// Unsorted represents an incoming lead awaiting acceptance
+type Unsorted struct {
+	UID        string                 `json:"uid"`
+	SourceUID  string                 `json:"source_uid,omitempty"`
+	SourceName string                 `json:"source_name,omitempty"`
+	Category   string                 `json:"category"` // sip, mail, forms, chats
+	PipelineID int                    `json:"pipeline_id,omitempty"`
+	CreatedAt  int64                  `json:"created_at,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	AccountID  int                    `json:"account_id,omitempty"`
+	Embedded   *Embedded              `json:"_embedded,omitempty"`
+}
+
+// UnsortedSummary represents unsorted statistics
+type UnsortedSummary struct {
+	Total           int            `json:"total"`
+	Accepted        int            `json:"accepted"`
+	Declined        int            `json:"declined"`
+	AverageSortTime int            `json:"average_sort_time"`
+	Categories      map[string]int `json:"categories"`
+}
+
+// UnsortedService handles communication with unsorted-related methods
+type UnsortedService struct {
+	client *Client
+}
+
+// UnsortedResponse represents the API response for unsorted list
+type UnsortedResponse struct {
+	Embedded struct {
+		Unsorted []Unsorted `json:"unsorted"`
+	} `json:"_embedded"`
+	Links Links `json:"_links"`
+	Page  int   `json:"_page,omitempty"`
+}
+
+// UnsortedFilter represents filter options for listing unsorted
+type UnsortedFilter struct {
+	Limit      int
+	Page       int
+	Category   []string
+	PipelineID int
+}
+
+// List retrieves a list of unsorted leads
+func (s *UnsortedService) List(ctx context.Context, filter *UnsortedFilter) ([]Unsorted, error) {
+	path := "/leads/unsorted"
+
+	if filter != nil {
+		path += "?"
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		for _, category := range filter.Category {
+			path += fmt.Sprintf("filter[category][]=%s&", category)
+		}
+		if filter.PipelineID > 0 {
+			path += fmt.Sprintf("filter[pipeline_id]=%d&", filter.PipelineID)
+		}
+	}
+
+	var resp UnsortedResponse
+	if err := s.client.GetJSON(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Unsorted, nil
+}
+
+// Create adds unsorted leads of a category (forms or sip). Items without
+// SourceName or SourceUID take them from the context source set with
+// WithRequestSource; both are required by the API.
+func (s *UnsortedService) Create(ctx context.Context, category string, items []*Unsorted) ([]Unsorted, error) {
+	if category != "forms" && category != "sip" {
+		return nil, fmt.Errorf("unsupported unsorted category %q", category)
+	}
+
+	type item struct {
+		SourceUID  string                 `json:"source_uid"`
+		SourceName string                 `json:"source_name"`
+		PipelineID int                    `json:"pipeline_id,omitempty"`
+		CreatedAt  int64                  `json:"created_at,omitempty"`
+		Metadata   map[string]interface{} `json:"metadata,omitempty"`
+		Embedded   *Embedded              `json:"_embedded,omitempty"`
+	}
+
+	source, _ := requestSource(ctx)
+
+	req := make([]item, len(items))
+	for i, u := range items {
+		req[i] = item{
+			SourceUID:  u.SourceUID,
+			SourceName: u.SourceName,
+			PipelineID: u.PipelineID,
+			CreatedAt:  u.CreatedAt,
+			Metadata:   u.Metadata,
+			Embedded:   u.Embedded,
+		}
+		if req[i].SourceUID == "" {
+			req[i].SourceUID = source.UID
+		}
+		if req[i].SourceName == "" {
+			req[i].SourceName = source.Name
+		}
+		if req[i].SourceUID == "" || req[i].SourceName == "" {
+			return nil, fmt.Errorf("source name and UID are required at index %d", i)
+		}
+	}
+
+	var resp UnsortedResponse
+	if err := s.client.PostJSON(ctx, "/leads/unsorted/"+category, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Unsorted, nil
+}
+
+// Summary retrieves unsorted statistics for the whole account
+func (s *UnsortedService) Summary(ctx context.Context) (*UnsortedSummary, error) {
+	return s.summary(ctx, "/leads/unsorted/summary")
+}
+
+// PipelineSummary retrieves unsorted statistics for a single pipeline
+func (s *UnsortedService) PipelineSummary(ctx context.Context, pipelineID int) (*UnsortedSummary, error) {
+	path := fmt.Sprintf("/leads/unsorted/summary?filter[pipeline_id]=%d", pipelineID)
+	return s.summary(ctx, path)
+}
+
+func (s *UnsortedService) summary(ctx context.Context, path string) (*UnsortedSummary, error) {
+	var summary UnsortedSummary
+	if err := s.client.GetJSON(ctx, path, &summary); err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// UnsortedActionResult represents the result of accepting or declining an
+// unsorted lead
+type UnsortedActionResult struct {
+	UID      string    `json:"uid"`
+	Embedded *Embedded `json:"_embedded,omitempty"`
+}
+
+// UnsortedBatchResult represents the outcome for one UID of a bulk operation
+type UnsortedBatchResult struct {
+	UID    string
+	Result *UnsortedActionResult
+	Err    error
+}
+
+// Accept accepts an unsorted lead. userID and statusID are optional.
+func (s *UnsortedService) Accept(ctx context.Context, uid string, userID, statusID int) (*UnsortedActionResult, error) {
+	type request struct {
+		UserID   int `json:"user_id,omitempty"`
+		StatusID int `json:"status_id,omitempty"`
+	}
+
+	req := request{UserID: userID, StatusID: statusID}
+	return s.action(ctx, uid, "accept", req)
+}
+
+// Decline declines an unsorted lead. userID is optional.
+func (s *UnsortedService) Decline(ctx context.Context, uid string, userID int) (*UnsortedActionResult, error) {
+	type request struct {
+		UserID int `json:"user_id,omitempty"`
+	}
+
+	req := request{UserID: userID}
+	return s.action(ctx, uid, "decline", req)
+}
+
+// AcceptBatch accepts several unsorted leads. The API handles one UID per
+// request, so the calls go through the rate limiter one after another and a
+// failure for one UID (e.g. already accepted) does not stop the others.
+func (s *UnsortedService) AcceptBatch(ctx context.Context, uids []string, userID, statusID int) []UnsortedBatchResult {
+	return s.batch(ctx, uids, func(uid string) (*UnsortedActionResult, error) {
+		return s.Accept(ctx, uid, userID, statusID)
+	})
+}
+
+// DeclineBatch declines several unsorted leads, see AcceptBatch
+func (s *UnsortedService) DeclineBatch(ctx context.Context, uids []string, userID int) []UnsortedBatchResult {
+	return s.batch(ctx, uids, func(uid string) (*UnsortedActionResult, error) {
+		return s.Decline(ctx, uid, userID)
+	})
+}
+
+func (s *UnsortedService) action(ctx context.Context, uid, action string, req interface{}) (*UnsortedActionResult, error) {
+	if uid == "" {
+		return nil, fmt.Errorf("unsorted UID is required")
+	}
+
+	path := fmt.Sprintf("/leads/unsorted/%s/%s", uid, action)
+
+	var result UnsortedActionResult
+	if err := s.client.PostJSON(ctx, path, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (s *UnsortedService) batch(ctx context.Context, uids []string, fn func(uid string) (*UnsortedActionResult, error)) []UnsortedBatchResult {
+	results := make([]UnsortedBatchResult, len(uids))
+	for i, uid := range uids {
+		results[i].UID = uid
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Result, results[i].Err = fn(uid)
+	}
+
+	return results
+}
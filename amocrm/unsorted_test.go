@@ -0,0 +1,91 @@
+package amocrm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestUnsortedService_Summary(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/leads/unsorted/summary" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+		if r.URL.Query().Get("filter[pipeline_id]") != "5" {
+			t.Errorf("Expected pipeline filter, got '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total": 7, "accepted": 2, "declined": 1, "average_sort_time": 60, "categories": {"forms": 4, "chats": 3}}`))
+	})
+
+	summary, err := client.Unsorted.PipelineSummary(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if summary.Total != 7 || summary.Categories["forms"] != 4 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+func TestUnsortedService_AcceptBatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v4/leads/unsorted/uid-1/accept":
+			w.Write([]byte(`{"uid": "uid-1", "_embedded": {"leads": [{"id": 100}]}}`))
+		case "/api/v4/leads/unsorted/uid-2/accept":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"title": "Bad Request", "detail": "Already accepted"}`))
+		default:
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+	})
+
+	results := client.Unsorted.AcceptBatch(context.Background(), []string{"uid-1", "uid-2"}, 0, 0)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	if results[0].Err != nil || results[0].Result.Embedded.Leads[0].ID != 100 {
+		t.Errorf("Unexpected result for uid-1: %+v", results[0])
+	}
+
+	if results[1].UID != "uid-2" || results[1].Err == nil {
+		t.Errorf("Expected error for uid-2, got %+v", results[1])
+	}
+}
+
+func TestUnsortedService_CreateWithRequestSource(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/leads/unsorted/forms" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `[{"source_uid":"site-1","source_name":"Сайт"},{"source_uid":"own","source_name":"Сайт"}]`
+		if string(body) != expected {
+			t.Errorf("Expected body %s, got %s", expected, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_embedded": {"unsorted": [{"uid": "a"}, {"uid": "b"}]}}`))
+	})
+
+	ctx := WithRequestSource(context.Background(), RequestSource{Name: "Сайт", UID: "site-1"})
+	created, err := client.Unsorted.Create(ctx, "forms", []*Unsorted{{}, {SourceUID: "own"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(created) != 2 || created[0].UID != "a" {
+		t.Errorf("Unexpected result: %+v", created)
+	}
+
+	if _, err := client.Unsorted.Create(context.Background(), "forms", []*Unsorted{{}}); err == nil {
+		t.Error("Expected error without a source")
+	}
+}
@@ -0,0 +1,134 @@
+package amocrm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// UsersService handles communication with user-related methods
+type UsersService struct {
+	client *Client
+
+	directoryMu        sync.Mutex
+	directory          map[int]User
+	directoryFetchedAt time.Time
+}
+
+// UsersResponse represents the API response for users list
+type UsersResponse struct {
+	Embedded struct {
+		Users []User `json:"users"`
+	} `json:"_embedded"`
+	Links     Links `json:"_links"`
+	Page      int   `json:"_page,omitempty"`
+	PageCount int   `json:"_page_count,omitempty"`
+
+	// Header holds the HTTP response headers, e.g. X-RateLimit-Remaining
+	Header http.Header `json:"-"`
+}
+
+// UsersFilter represents filter options for listing users
+type UsersFilter struct {
+	Limit int
+	Page  int
+	With  string // comma-separated list: role, group, uuid, amojo_id, user_rank, phone_number
+}
+
+// usersDirectoryTTL is how long Map results are cached
+const usersDirectoryTTL = 10 * time.Minute
+
+// List retrieves a list of users
+func (s *UsersService) List(ctx context.Context, filter *UsersFilter) ([]User, error) {
+	resp, err := s.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Embedded.Users, nil
+}
+
+// ListWithResponse retrieves a list of users with pagination links, the
+// page count and the response headers
+func (s *UsersService) ListWithResponse(ctx context.Context, filter *UsersFilter) (*UsersResponse, error) {
+	path := "/users"
+
+	if filter != nil {
+		path += "?"
+		if filter.Limit > 0 {
+			path += fmt.Sprintf("limit=%d&", filter.Limit)
+		}
+		if filter.Page > 0 {
+			path += fmt.Sprintf("page=%d&", filter.Page)
+		}
+		if filter.With != "" {
+			path += fmt.Sprintf("with=%s&", filter.With)
+		}
+	}
+
+	var resp UsersResponse
+	header, err := s.client.getJSONHeader(ctx, path, &resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header = header
+
+	return &resp, nil
+}
+
+// GetByID retrieves a user by ID
+func (s *UsersService) GetByID(ctx context.Context, id int) (*User, error) {
+	path := fmt.Sprintf("/users/%d", id)
+
+	var user User
+	if err := s.client.GetJSON(ctx, path, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// Map returns all account users keyed by ID, e.g. to resolve the
+// responsible_user_id of listed entities. The directory is fetched page by
+// page and cached for 10 minutes; use RefreshMap to reload it on demand.
+// The returned map is shared and must not be modified.
+func (s *UsersService) Map(ctx context.Context) (map[int]User, error) {
+	s.directoryMu.Lock()
+	directory, fetchedAt := s.directory, s.directoryFetchedAt
+	s.directoryMu.Unlock()
+
+	if directory != nil && timeNow().Sub(fetchedAt) < usersDirectoryTTL {
+		return directory, nil
+	}
+
+	return s.RefreshMap(ctx)
+}
+
+// RefreshMap reloads the user directory cached by Map
+func (s *UsersService) RefreshMap(ctx context.Context) (map[int]User, error) {
+	directory := make(map[int]User)
+	filter := &UsersFilter{Limit: 250, Page: 1}
+	for {
+		resp, err := s.ListWithResponse(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range resp.Embedded.Users {
+			directory[user.ID] = user
+		}
+		if !resp.Links.HasNext() || len(resp.Embedded.Users) == 0 {
+			break
+		}
+		filter.Page++
+	}
+
+	s.directoryMu.Lock()
+	s.directory = directory
+	s.directoryFetchedAt = timeNow()
+	s.directoryMu.Unlock()
+
+	return directory, nil
+}
@@ -0,0 +1,68 @@
+package amocrm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestUsersService_Map(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/v4/users" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"_embedded": {"users": [{"id": 1, "name": "Anna"}]}, "_links": {"next": {"href": "next"}}}`))
+			return
+		}
+		w.Write([]byte(`{"_embedded": {"users": [{"id": 2, "name": "Boris"}]}}`))
+	})
+
+	ctx := context.Background()
+	users, err := client.Users.Map(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[2].Name != "Boris" {
+		t.Errorf("Unexpected users: %+v", users)
+	}
+
+	if _, err := client.Users.Map(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected cached directory after 2 requests, got %d", requests)
+	}
+
+	if _, err := client.Users.RefreshMap(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("Expected refresh to reload the directory, got %d requests", requests)
+	}
+}
+
+func TestUsersService_ListWithResponseCoalesced(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "3")
+		w.Write([]byte(`{"_page_count": 2, "_embedded": {"users": [{"id": 1}]}}`))
+	})
+	WithRequestCoalescing(true)(client)
+
+	resp, err := client.Users.ListWithResponse(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if resp.PageCount != 2 || len(resp.Embedded.Users) != 1 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "3" {
+		t.Errorf("Expected X-RateLimit-Remaining '3', got '%s'", got)
+	}
+}
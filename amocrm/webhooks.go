@@ -2,7 +2,17 @@ package amocrm
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // Webhook represents an AmoCRM webhook
@@ -10,9 +20,41 @@ type Webhook struct {
 	ID          string   `json:"id,omitempty"`
 	Destination string   `json:"destination"`
 	Settings    []string `json:"settings"`
-	Disabled    bool     `json:"disabled,omitempty"`
+	Disabled    bool     `json:"disabled,omitempty"` // set by AmoCRM
 }
 
+// Webhook event settings
+const (
+	WebhookEventAddLead             = "add_lead"
+	WebhookEventUpdateLead          = "update_lead"
+	WebhookEventDeleteLead          = "delete_lead"
+	WebhookEventRestoreLead         = "restore_lead"
+	WebhookEventStatusLead          = "status_lead"
+	WebhookEventResponsibleLead     = "responsible_lead"
+	WebhookEventNoteLead            = "note_lead"
+	WebhookEventAddContact          = "add_contact"
+	WebhookEventUpdateContact       = "update_contact"
+	WebhookEventDeleteContact       = "delete_contact"
+	WebhookEventRestoreContact      = "restore_contact"
+	WebhookEventResponsibleContact  = "responsible_contact"
+	WebhookEventNoteContact         = "note_contact"
+	WebhookEventAddCompany          = "add_company"
+	WebhookEventUpdateCompany       = "update_company"
+	WebhookEventDeleteCompany       = "delete_company"
+	WebhookEventRestoreCompany      = "restore_company"
+	WebhookEventResponsibleCompany  = "responsible_company"
+	WebhookEventNoteCompany         = "note_company"
+	WebhookEventAddCustomer         = "add_customer"
+	WebhookEventUpdateCustomer      = "update_customer"
+	WebhookEventDeleteCustomer      = "delete_customer"
+	WebhookEventResponsibleCustomer = "responsible_customer"
+	WebhookEventNoteCustomer        = "note_customer"
+	WebhookEventAddTask             = "add_task"
+	WebhookEventUpdateTask          = "update_task"
+	WebhookEventDeleteTask          = "delete_task"
+	WebhookEventResponsibleTask     = "responsible_task"
+)
+
 // WebhooksService handles communication with webhook-related methods
 type WebhooksService struct {
 	client *Client
@@ -38,6 +80,13 @@ func (s *WebhooksService) List(ctx context.Context) ([]Webhook, error) {
 
 // Subscribe creates a new webhook subscription
 func (s *WebhooksService) Subscribe(ctx context.Context, webhook *Webhook) error {
+	if webhook.Destination == "" {
+		return fmt.Errorf("webhook destination is required")
+	}
+	if len(webhook.Settings) == 0 {
+		return fmt.Errorf("at least one webhook event setting is required")
+	}
+
 	type request struct {
 		Webhooks []Webhook `json:"webhooks"`
 	}
@@ -54,8 +103,291 @@ func (s *WebhooksService) Subscribe(ctx context.Context, webhook *Webhook) error
 	return nil
 }
 
-// Unsubscribe deletes a webhook subscription
-func (s *WebhooksService) Unsubscribe(ctx context.Context, webhookID string) error {
-	path := fmt.Sprintf("/webhooks/%s", webhookID)
-	return s.client.DeleteJSON(ctx, path)
+// Unsubscribe deletes the webhook subscription of a destination URL
+func (s *WebhooksService) Unsubscribe(ctx context.Context, destination string) error {
+	if destination == "" {
+		return fmt.Errorf("webhook destination is required")
+	}
+
+	type request struct {
+		Destination string `json:"destination"`
+	}
+
+	req := request{
+		Destination: destination,
+	}
+
+	_, err := s.client.DoJSON(ctx, "DELETE", "/webhooks", req, nil)
+	return err
+}
+
+// AccountRef identifies the account an incoming webhook was sent from
+type AccountRef struct {
+	ID        int    `json:"id"`
+	Subdomain string `json:"subdomain"`
+	Link      string `json:"link,omitempty"`
+}
+
+// WebhookAccount reads the account block of an incoming webhook request.
+// Multi-account receivers use it to pick the Client for the right tenant.
+// The request form is cached by net/http, so the body can still be parsed
+// afterwards.
+func WebhookAccount(r *http.Request) (*AccountRef, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook form: %w", err)
+	}
+
+	account, err := parseWebhookAccount(r.PostForm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// parseWebhookAccount decodes the account[...] keys of a webhook form
+func parseWebhookAccount(values url.Values) (AccountRef, error) {
+	account := AccountRef{
+		Subdomain: values.Get("account[subdomain]"),
+		Link:      values.Get("account[_links][self]"),
+	}
+
+	if id := values.Get("account[id]"); id != "" {
+		accountID, err := strconv.Atoi(id)
+		if err != nil {
+			return AccountRef{}, fmt.Errorf("invalid account id %q: %w", id, err)
+		}
+		account.ID = accountID
+	}
+
+	if account.ID == 0 && account.Subdomain == "" {
+		return AccountRef{}, fmt.Errorf("webhook has no account block")
+	}
+
+	return account, nil
+}
+
+// VerifyWebhookSignature reports whether signature, the X-Signature header
+// of a webhook delivery, is the HMAC-SHA1 of the raw body keyed with the
+// integration secret. The hex digest is compared case-insensitively and in
+// constant time.
+func VerifyWebhookSignature(body []byte, signature, secret string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// WebhookPayload is a decoded incoming webhook
+type WebhookPayload struct {
+	Account   AccountRef
+	Leads     WebhookEvents
+	Contacts  WebhookEvents
+	Companies WebhookEvents
+	Customers WebhookEvents
+	Tasks     WebhookEvents
+}
+
+// WebhookEvents groups the entities of a webhook by action
+type WebhookEvents struct {
+	Add         []WebhookEntity
+	Update      []WebhookEntity
+	Delete      []WebhookEntity
+	Restore     []WebhookEntity
+	Status      []WebhookEntity
+	Responsible []WebhookEntity
+	Note        []WebhookEntity
+}
+
+// WebhookEntity is an entity of a webhook event. Fields holds the sent
+// fields by their path with dots between nesting levels, e.g. "name",
+// "old_status_id" or "custom_fields.0.values.0.value".
+type WebhookEntity struct {
+	ID     int
+	Fields map[string]string
+}
+
+// Get returns the value of a field, or "" if it was not sent
+func (e *WebhookEntity) Get(field string) string {
+	return e.Fields[field]
+}
+
+// Int returns the value of a numeric field, or 0 if it was not sent
+func (e *WebhookEntity) Int(field string) int {
+	n, _ := strconv.Atoi(e.Fields[field])
+	return n
+}
+
+// ParseWebhook decodes an incoming webhook request. AmoCRM sends webhooks
+// as application/x-www-form-urlencoded with nested bracket keys such as
+// leads[status][0][old_status_id], not as JSON.
+func ParseWebhook(r *http.Request) (*WebhookPayload, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook form: %w", err)
+	}
+
+	return parseWebhookPayload(r.PostForm)
+}
+
+// parseWebhookPayload decodes the entity and account keys of a webhook form
+func parseWebhookPayload(values url.Values) (*WebhookPayload, error) {
+	account, err := parseWebhookAccount(values)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &WebhookPayload{Account: account}
+
+	type entityKey struct {
+		entity, action string
+		index          int
+	}
+	entities := make(map[entityKey]*WebhookEntity)
+	var keys []entityKey
+
+	for key, value := range values {
+		// leads[add][0][custom_fields][0][id] -> leads, add, 0, custom_fields, 0, id
+		parts := strings.Split(strings.ReplaceAll(strings.TrimSuffix(key, "]"), "]", ""), "[")
+		if len(parts) < 4 || payload.events(parts[0]) == nil {
+			continue
+		}
+
+		index, err := strconv.Atoi(parts[2])
+		if err != nil {
+			continue
+		}
+
+		k := entityKey{entity: parts[0], action: parts[1], index: index}
+		entity, ok := entities[k]
+		if !ok {
+			entity = &WebhookEntity{Fields: make(map[string]string)}
+			entities[k] = entity
+			keys = append(keys, k)
+		}
+
+		field := strings.Join(parts[3:], ".")
+		entity.Fields[field] = value[0]
+		if field == "id" {
+			id, err := strconv.Atoi(value[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s id %q: %w", k.entity, value[0], err)
+			}
+			entity.ID = id
+		}
+	}
+
+	// Keep the order of the entities within each action
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].entity != keys[j].entity {
+			return keys[i].entity < keys[j].entity
+		}
+		if keys[i].action != keys[j].action {
+			return keys[i].action < keys[j].action
+		}
+		return keys[i].index < keys[j].index
+	})
+
+	for _, k := range keys {
+		if list := payload.events(k.entity).action(k.action); list != nil {
+			*list = append(*list, *entities[k])
+		}
+	}
+
+	return payload, nil
+}
+
+// ErrWebhookRetry marks a webhook handler error after which AmoCRM should
+// redeliver the webhook. Wrap it with RetryWebhook.
+var ErrWebhookRetry = errors.New("webhook should be redelivered")
+
+// RetryWebhook wraps err so that the webhook handler asks AmoCRM to
+// redeliver the webhook
+func RetryWebhook(err error) error {
+	return fmt.Errorf("%w: %w", ErrWebhookRetry, err)
+}
+
+// WebhookHandlerFunc processes a decoded webhook
+type WebhookHandlerFunc func(ctx context.Context, payload *WebhookPayload) error
+
+// NewWebhookHandler returns an http.Handler that decodes incoming webhooks
+// and passes them to fn.
+//
+// AmoCRM treats any response other than 2xx, or no response within
+// 2 seconds, as a failed delivery and sends the webhook again a few times
+// with growing delays; webhooks that keep failing are disabled by AmoCRM.
+// The handler therefore maps the result of fn as follows:
+//   - nil: 200, the webhook is acknowledged
+//   - an error wrapping ErrWebhookRetry: 503, AmoCRM redelivers the webhook
+//   - any other error: logged as a permanent failure and acknowledged with
+//     200 so the webhook is not redelivered
+//
+// Requests that cannot be decoded are treated as permanent failures too.
+// A nil logger uses slog.Default.
+func NewWebhookHandler(fn WebhookHandlerFunc, logger *slog.Logger) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ParseWebhook(r)
+		if err != nil {
+			logger.Error("Failed to decode webhook", "error", err)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if err := fn(r.Context(), payload); err != nil {
+			if errors.Is(err, ErrWebhookRetry) {
+				logger.Warn("Webhook processing failed, requesting redelivery",
+					"account_id", payload.Account.ID, "error", err)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			logger.Error("Webhook processing failed permanently",
+				"account_id", payload.Account.ID, "error", err)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (p *WebhookPayload) events(entity string) *WebhookEvents {
+	switch entity {
+	case "leads":
+		return &p.Leads
+	case "contacts":
+		return &p.Contacts
+	case "companies":
+		return &p.Companies
+	case "customers":
+		return &p.Customers
+	case "task", "tasks":
+		return &p.Tasks
+	}
+	return nil
+}
+
+func (e *WebhookEvents) action(action string) *[]WebhookEntity {
+	switch action {
+	case "add":
+		return &e.Add
+	case "update":
+		return &e.Update
+	case "delete":
+		return &e.Delete
+	case "restore":
+		return &e.Restore
+	case "status":
+		return &e.Status
+	case "responsible":
+		return &e.Responsible
+	case "note":
+		return &e.Note
+	}
+	return nil
 }
@@ -0,0 +1,194 @@
+package amocrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newWebhookRequest(values url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestWebhookAccount(t *testing.T) {
+	r := newWebhookRequest(url.Values{
+		"account[id]":           {"29085925"},
+		"account[subdomain]":    {"testsubdomain"},
+		"account[_links][self]": {"https://testsubdomain.amocrm.ru"},
+		"leads[add][0][id]":     {"12345"},
+	})
+
+	account, err := WebhookAccount(r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if account.ID != 29085925 {
+		t.Errorf("Expected account ID 29085925, got %d", account.ID)
+	}
+
+	if account.Subdomain != "testsubdomain" {
+		t.Errorf("Expected subdomain 'testsubdomain', got '%s'", account.Subdomain)
+	}
+
+	if account.Link != "https://testsubdomain.amocrm.ru" {
+		t.Errorf("Unexpected account link '%s'", account.Link)
+	}
+}
+
+func TestWebhookAccountMissing(t *testing.T) {
+	r := newWebhookRequest(url.Values{"leads[add][0][id]": {"12345"}})
+
+	if _, err := WebhookAccount(r); err == nil {
+		t.Error("Expected error for webhook without account block")
+	}
+}
+
+func TestWebhooksService_Unsubscribe(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v4/webhooks" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			Destination string `json:"destination"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Destination != "https://example.com/webhook" {
+			t.Errorf("Expected destination in body, got '%s'", body.Destination)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Webhooks.Unsubscribe(context.Background(), "https://example.com/webhook"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestWebhooksService_SubscribeRequiresSettings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("No request expected without settings")
+	})
+
+	err := client.Webhooks.Subscribe(context.Background(), &Webhook{Destination: "https://example.com/webhook"})
+	if err == nil {
+		t.Error("Expected error for empty settings")
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	r := newWebhookRequest(url.Values{
+		"account[id]":        {"29085925"},
+		"account[subdomain]": {"testsubdomain"},
+
+		"leads[status][0][id]":            {"100"},
+		"leads[status][0][status_id]":     {"142"},
+		"leads[status][0][old_status_id]": {"40"},
+		"leads[status][0][pipeline_id]":   {"7"},
+
+		"leads[status][1][id]":        {"101"},
+		"leads[status][1][status_id]": {"143"},
+
+		"leads[add][0][id]":                                 {"102"},
+		"leads[add][0][name]":                               {"Новая сделка"},
+		"leads[add][0][custom_fields][0][id]":               {"555"},
+		"leads[add][0][custom_fields][0][values][0][value]": {"Сайт"},
+
+		"contacts[delete][0][id]": {"200"},
+	})
+
+	payload, err := ParseWebhook(r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if payload.Account.Subdomain != "testsubdomain" {
+		t.Errorf("Expected subdomain 'testsubdomain', got '%s'", payload.Account.Subdomain)
+	}
+
+	status := payload.Leads.Status
+	if len(status) != 2 || status[0].ID != 100 || status[1].ID != 101 {
+		t.Fatalf("Unexpected status events: %+v", status)
+	}
+	if status[0].Int("old_status_id") != 40 || status[0].Int("status_id") != 142 {
+		t.Errorf("Unexpected status change fields: %+v", status[0].Fields)
+	}
+
+	added := payload.Leads.Add
+	if len(added) != 1 || added[0].Get("name") != "Новая сделка" {
+		t.Fatalf("Unexpected add events: %+v", added)
+	}
+	if got := added[0].Get("custom_fields.0.values.0.value"); got != "Сайт" {
+		t.Errorf("Expected custom field value 'Сайт', got '%s'", got)
+	}
+
+	if len(payload.Contacts.Delete) != 1 || payload.Contacts.Delete[0].ID != 200 {
+		t.Errorf("Unexpected contact delete events: %+v", payload.Contacts.Delete)
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte("leads%5Badd%5D%5B0%5D%5Bid%5D=12345")
+	signature := "df359f729db9ac0048197e49ddb3d9d7cee1fa22"
+
+	if !VerifyWebhookSignature(body, signature, "secret-key") {
+		t.Error("Expected valid signature")
+	}
+	if !VerifyWebhookSignature(body, strings.ToUpper(signature), "secret-key") {
+		t.Error("Expected uppercase hex digest to be accepted")
+	}
+	if VerifyWebhookSignature(body, signature, "other-key") {
+		t.Error("Expected signature with another secret to be rejected")
+	}
+	if VerifyWebhookSignature([]byte("forged"), signature, "secret-key") {
+		t.Error("Expected signature of another body to be rejected")
+	}
+	if VerifyWebhookSignature(body, "not-hex", "secret-key") {
+		t.Error("Expected malformed signature to be rejected")
+	}
+}
+
+func TestNewWebhookHandler(t *testing.T) {
+	values := url.Values{
+		"account[id]":       {"1"},
+		"leads[add][0][id]": {"10"},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"ack", nil, http.StatusOK},
+		{"retry", RetryWebhook(errors.New("database is down")), http.StatusServiceUnavailable},
+		{"permanent", errors.New("unknown pipeline"), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(func(ctx context.Context, payload *WebhookPayload) error {
+				if len(payload.Leads.Add) != 1 || payload.Leads.Add[0].ID != 10 {
+					t.Errorf("Unexpected payload: %+v", payload.Leads)
+				}
+				return tt.err
+			}, logger)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newWebhookRequest(values))
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
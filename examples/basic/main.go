@@ -35,23 +35,8 @@ func main() {
 		FirstName: "Иван",
 		LastName:  "Иванов",
 		CustomFieldsValues: []amocrm.CustomFieldValue{
-			{
-				FieldID: 123456, // ID поля "Телефон" (замените на реальный)
-				Values: []amocrm.FieldValue{
-					{
-						Value:    "+79001234567",
-						EnumCode: "WORK",
-					},
-				},
-			},
-			{
-				FieldID: 123457, // ID поля "Email" (замените на реальный)
-				Values: []amocrm.FieldValue{
-					{
-						Value: "ivan@example.com",
-					},
-				},
-			},
+			amocrm.NewMultiField(123456, "WORK", "+79001234567"),    // ID поля "Телефон" (замените на реальный)
+			amocrm.NewMultiField(123457, "WORK", "ivan@example.com"), // ID поля "Email" (замените на реальный)
 		},
 	}
 
@@ -81,8 +66,8 @@ func main() {
 	lead := &amocrm.Lead{
 		Name:       "Новая сделка",
 		Price:      100000,
-		PipelineID: 1,      // ID воронки (замените на реальный)
-		StatusID:   142,    // ID статуса (замените на реальный)
+		PipelineID: 1,  // ID воронки (замените на реальный)
+		StatusID:   10, // ID рабочего этапа воронки (замените на реальный)
 	}
 
 	createdLead, err := client.Leads.Create(ctx, lead)
@@ -140,5 +125,12 @@ func main() {
 	}
 	fmt.Printf("Обновлен контакт: %s (ID: %d)\n\n", updatedContact.Name, updatedContact.ID)
 
+	// Закрываем сделку как успешную (статус amocrm.StatusWon)
+	fmt.Println("=== Закрытие сделки ===")
+	if _, err := client.Leads.Win(ctx, createdLead.ID); err != nil {
+		log.Fatalf("Ошибка закрытия сделки: %v", err)
+	}
+	fmt.Printf("Сделка %d закрыта как успешно реализованная\n\n", createdLead.ID)
+
 	fmt.Println("=== Готово! ===")
 }
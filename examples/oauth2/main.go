@@ -56,9 +56,9 @@ func main() {
 			log.Fatalf("Ошибка обмена кода: %v", err)
 		}
 		
-		fmt.Println("Авторизация успешна! Токен сохранен.\n")
+		fmt.Print("Авторизация успешна! Токен сохранен.\n\n")
 	} else {
-		fmt.Println("=== Используем сохраненный токен ===\n")
+		fmt.Print("=== Используем сохраненный токен ===\n\n")
 	}
 
 	// Получаем информацию об аккаунте
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/dedomorozoff/amocrm-go-v4/amocrm"
 )
@@ -23,11 +22,11 @@ func main() {
 	webhook := &amocrm.Webhook{
 		Destination: "https://example.com/webhook",
 		Settings: []string{
-			"add_lead",
-			"update_lead",
-			"delete_lead",
-			"add_contact",
-			"update_contact",
+			amocrm.WebhookEventAddLead,
+			amocrm.WebhookEventUpdateLead,
+			amocrm.WebhookEventDeleteLead,
+			amocrm.WebhookEventAddContact,
+			amocrm.WebhookEventUpdateContact,
 		},
 	}
 
@@ -54,31 +53,21 @@ func main() {
 
 	// Пример обработки входящего webhook
 	fmt.Println("=== Обработка входящего webhook ===")
-	fmt.Println("Пример структуры для обработки webhook:")
-	
-	exampleWebhookData := `
-	{
-		"leads": {
-			"add": [
-				{
-					"id": 12345,
-					"name": "Новая сделка",
-					"status_id": 142,
-					"price": 100000,
-					"responsible_user_id": 123,
-					"created_at": 1234567890,
-					"updated_at": 1234567890
-				}
-			]
-		},
-		"account": {
-			"id": 123,
-			"subdomain": "testsubdomain"
-		}
-	}
-	`
-	
-	fmt.Println(exampleWebhookData)
+	fmt.Println("Обработчик: http.Handle(\"/webhook\", handleWebhook)")
 
 	fmt.Println("\n=== Готово! ===")
 }
+
+// handleWebhook обрабатывает входящий webhook. AmoCRM присылает данные
+// в формате application/x-www-form-urlencoded, а не JSON. Ошибка,
+// обёрнутая в RetryWebhook, приводит к повторной отправке webhook.
+var handleWebhook = amocrm.NewWebhookHandler(func(ctx context.Context, payload *amocrm.WebhookPayload) error {
+	for _, lead := range payload.Leads.Add {
+		fmt.Printf("Новая сделка %d: %s\n", lead.ID, lead.Get("name"))
+	}
+	for _, lead := range payload.Leads.Status {
+		fmt.Printf("Сделка %d: статус %d -> %d\n", lead.ID, lead.Int("old_status_id"), lead.Int("status_id"))
+	}
+
+	return nil
+}, nil)